@@ -75,6 +75,10 @@ type ServerOption struct {
 	// WorkerThreadsForGC is the number of threads for recycling jobs
 	// The larger the number, the faster the job recycling, but requires more CPU load.
 	WorkerThreadsForGC uint32
+	// PluginsDir is the directory the custom job plugins are loaded from.
+	PluginsDir string
+	// PluginWebhookConfig is the file of the configuration of the job plugins served by webhooks.
+	PluginWebhookConfig string
 }
 
 type DecryptFunc func(c *ServerOption) error
@@ -107,6 +111,8 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.InheritOwnerAnnotations, "inherit-owner-annotations", true, "Enable inherit owner annotations for pods when create podgroup; it is enabled by default")
 	fs.Uint32Var(&s.WorkerThreadsForPG, "worker-threads-for-podgroup", defaultPodGroupWorkers, "The number of threads syncing podgroup operations. The larger the number, the faster the podgroup processing, but requires more CPU load.")
 	fs.Uint32Var(&s.WorkerThreadsForGC, "worker-threads-for-gc", defaultGCWorkers, "The number of threads for recycling jobs. The larger the number, the faster the job recycling, but requires more CPU load.")
+	fs.StringVar(&s.PluginsDir, "plugins-dir", "", "vc-controller-manager will load custom job plugins which are in this directory")
+	fs.StringVar(&s.PluginWebhookConfig, "plugin-webhook-config", "", "The file of the configuration of the job plugins served by webhooks")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
@@ -38,6 +38,8 @@ import (
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
 	"volcano.sh/volcano/cmd/controller-manager/app/options"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/webhook"
 	"volcano.sh/volcano/pkg/kube"
 	"volcano.sh/volcano/pkg/signals"
 )
@@ -55,6 +57,23 @@ func Run(opt *options.ServerOption) error {
 		}
 	}
 
+	if opt.PluginsDir != "" {
+		if err := plugins.LoadCustomPlugins(opt.PluginsDir); err != nil {
+			klog.Errorf("Fail to load custom job plugins: %v", err)
+			return err
+		}
+	}
+	if opt.PluginWebhookConfig != "" {
+		conf, err := webhook.LoadConfiguration(opt.PluginWebhookConfig)
+		if err != nil {
+			return err
+		}
+		if err := webhook.RegisterPlugins(conf); err != nil {
+			klog.Errorf("Fail to register job plugins served by webhooks: %v", err)
+			return err
+		}
+	}
+
 	run := startControllers(config, opt)
 
 	ctx := signals.SetupSignalContext()
@@ -121,6 +140,7 @@ func startControllers(config *rest.Config, opt *options.ServerOption) func(ctx c
 
 	// TODO: add user agent for different controllers
 	controllerOpt.KubeClient = kubeclientset.NewForConfigOrDie(config)
+	controllerOpt.KubeConfig = config
 	controllerOpt.VolcanoClient = vcclientset.NewForConfigOrDie(config)
 	controllerOpt.SharedInformerFactory = informers.NewSharedInformerFactory(controllerOpt.KubeClient, 0)
 	controllerOpt.VCSharedInformerFactory = informerfactory.NewSharedInformerFactory(controllerOpt.VolcanoClient, 0)
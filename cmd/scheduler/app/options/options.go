@@ -34,6 +34,7 @@ const (
 	defaultSchedulerPeriod = time.Second
 	defaultQueue           = "default"
 	defaultListenAddress   = ":8080"
+	defaultSessionHistory  = 10
 	defaultHealthzAddress  = ":11251"
 	defaultPluginsDir      = ""
 
@@ -46,6 +47,7 @@ const (
 	defaultPercentageOfNodesToFind    = 0
 	defaultLockObjectNamespace        = "volcano-system"
 	defaultNodeWorkers                = 20
+	defaultTracingSampleRatio         = 1.0
 )
 
 // ServerOption is the main context object for the controller manager.
@@ -59,7 +61,10 @@ type ServerOption struct {
 	CaCertData        []byte
 	SchedulerNames    []string
 	SchedulerConf     string
-	SchedulePeriod    time.Duration
+	// SchedulerConfResource is the name of the cluster scoped SchedulerConfiguration resource
+	// the scheduler configuration is read from instead of SchedulerConf
+	SchedulerConfResource string
+	SchedulePeriod        time.Duration
 	// leaderElection defines the configuration of leader election.
 	LeaderElection config.LeaderElectionConfiguration
 	// Deprecated: use ResourceNamespace instead.
@@ -90,6 +95,27 @@ type ServerOption struct {
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
 	// is always null, these provisioners usually are host path csi controllers like rancher.io/local-path and hostpath.csi.k8s.io.
 	IgnoredCSIProvisioners []string
+
+	// SimulateSnapshot is the path of a cache snapshot file dumped by the scheduler; if set, vc-scheduler runs one
+	// scheduling cycle against it offline, writes the result to SimulateOutput and exits
+	SimulateSnapshot string
+	SimulateOutput   string
+
+	// EnableDebugHandler serves the scheduler cache and the decisions of the last DebugSessionHistory
+	// sessions on ListenAddress, requests are authenticated with the bearer token in DebugTokenFile
+	EnableDebugHandler  bool
+	DebugTokenFile      string
+	DebugSessionHistory int
+
+	// EnableSchedulingTrace records the predicate verdict and score of each plugin per node for all tasks,
+	// instead of only for the PodGroups annotated with volcano.sh/scheduling-trace
+	EnableSchedulingTrace bool
+
+	// TracingEndpoint is the address of the OTLP gRPC collector the spans of the scheduling cycles are
+	// exported to, tracing is disabled if it is empty
+	TracingEndpoint    string
+	TracingInsecure    bool
+	TracingSampleRatio float64
 }
 
 // DecryptFunc is custom function to parse ca file
@@ -100,7 +126,11 @@ var ServerOpts *ServerOption
 
 // NewServerOption creates a new CMServer with a default config.
 func NewServerOption() *ServerOption {
-	return &ServerOption{}
+	return &ServerOption{
+		MinNodesToFind:             defaultMinNodesToFind,
+		MinPercentageOfNodesToFind: defaultMinPercentageOfNodesToFind,
+		PercentageOfNodesToFind:    defaultPercentageOfNodesToFind,
+	}
 }
 
 // AddFlags adds flags for a specific CMServer to the specified FlagSet.
@@ -117,6 +147,7 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	// volcano scheduler will ignore pods with scheduler names other than specified with the option
 	fs.StringArrayVar(&s.SchedulerNames, "scheduler-name", []string{defaultSchedulerName}, "vc-scheduler will handle pods whose .spec.SchedulerName is same as scheduler-name")
 	fs.StringVar(&s.SchedulerConf, "scheduler-conf", "", "The absolute path of scheduler configuration file")
+	fs.StringVar(&s.SchedulerConfResource, "scheduler-conf-resource", "", "The name of the cluster scoped SchedulerConfiguration resource to read the scheduler configuration from; if set, --scheduler-conf is ignored")
 	fs.DurationVar(&s.SchedulePeriod, "schedule-period", defaultSchedulerPeriod, "The period between each scheduling cycle")
 	fs.StringVar(&s.DefaultQueue, "default-queue", defaultQueue, "The default queue name of the job")
 	fs.BoolVar(&s.PrintVersion, "version", false, "Show version and quit")
@@ -146,6 +177,15 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
+	fs.StringVar(&s.SimulateSnapshot, "simulate-snapshot", "", "Run one scheduling cycle offline against the cluster snapshot in this file with the configuration of --scheduler-conf, then exit")
+	fs.StringVar(&s.SimulateOutput, "simulate-output", "", "The file the simulation result is written to in json format; stdout by default")
+	fs.BoolVar(&s.EnableDebugHandler, "enable-debug-handler", false, "Serve the scheduler cache and the last session decisions under /debug/scheduler/ on --listen-address; it is false by default")
+	fs.StringVar(&s.DebugTokenFile, "debug-token-file", "", "The file containing the bearer token required by the debug handler")
+	fs.IntVar(&s.DebugSessionHistory, "debug-session-history", defaultSessionHistory, "The number of sessions whose decisions are kept for the debug handler")
+	fs.BoolVar(&s.EnableSchedulingTrace, "scheduling-trace", false, "Record the predicate verdict and score of each plugin per node for all tasks, not only for PodGroups annotated with volcano.sh/scheduling-trace=true; it is false by default")
+	fs.StringVar(&s.TracingEndpoint, "tracing-endpoint", "", "The address of the OTLP gRPC collector the OpenTelemetry spans of the sessions, actions, plugins and allocate attempts are exported to, like otel-collector:4317; tracing is disabled if it is empty")
+	fs.BoolVar(&s.TracingInsecure, "tracing-insecure", false, "Connect to the OTLP collector without transport security; it is false by default")
+	fs.Float64Var(&s.TracingSampleRatio, "tracing-sample-ratio", defaultTracingSampleRatio, "The ratio of the scheduling cycles which are traced, between 0 and 1")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
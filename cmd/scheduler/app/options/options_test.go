@@ -76,6 +76,8 @@ func TestAddFlags(t *testing.T) {
 		PercentageOfNodesToFind:    defaultPercentageOfNodesToFind,
 		NodeWorkerThreads:          defaultNodeWorkers,
 		CacheDumpFileDir:           "/tmp",
+		DebugSessionHistory:        defaultSessionHistory,
+		TracingSampleRatio:         defaultTracingSampleRatio,
 	}
 
 	if !equality.Semantic.DeepEqual(expected, s) {
@@ -27,7 +27,9 @@ import (
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/kube"
 	"volcano.sh/volcano/pkg/scheduler"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
 	"volcano.sh/volcano/pkg/signals"
 	commonutil "volcano.sh/volcano/pkg/util"
 	"volcano.sh/volcano/pkg/version"
@@ -60,11 +62,6 @@ func Run(opt *options.ServerOption) error {
 		version.PrintVersionAndExit()
 	}
 
-	config, err := kube.BuildConfig(opt.KubeClientOptions)
-	if err != nil {
-		return err
-	}
-
 	if opt.PluginsDir != "" {
 		err := framework.LoadCustomPlugins(opt.PluginsDir)
 		if err != nil {
@@ -73,18 +70,49 @@ func Run(opt *options.ServerOption) error {
 		}
 	}
 
+	if opt.SimulateSnapshot != "" {
+		return scheduler.Simulate(opt)
+	}
+
+	config, err := kube.BuildConfig(opt.KubeClientOptions)
+	if err != nil {
+		return err
+	}
+
 	sched, err := scheduler.NewScheduler(config, opt)
 	if err != nil {
 		panic(err)
 	}
 
-	if opt.EnableMetrics {
+	if opt.EnableDebugHandler {
+		handler, err := sched.DebugHandler(opt.DebugTokenFile)
+		if err != nil {
+			return err
+		}
+		http.Handle(schedcache.DebugPathPrefix, handler)
+	}
+
+	if opt.EnableMetrics || opt.EnableDebugHandler {
 		go func() {
-			http.Handle("/metrics", promHandler())
-			klog.Fatalf("Prometheus Http Server failed %s", http.ListenAndServe(opt.ListenAddress, nil))
+			if opt.EnableMetrics {
+				http.Handle("/metrics", promHandler())
+			}
+			klog.Fatalf("Http Server failed %s", http.ListenAndServe(opt.ListenAddress, nil))
 		}()
 	}
 
+	if opt.TracingEndpoint != "" {
+		shutdown, err := tracing.Start(context.Background(), tracing.Options{
+			Endpoint:    opt.TracingEndpoint,
+			Insecure:    opt.TracingInsecure,
+			SampleRatio: opt.TracingSampleRatio,
+		})
+		if err != nil {
+			return err
+		}
+		defer shutdown(context.Background())
+	}
+
 	if opt.EnableHealthz {
 		if err := helpers.StartHealthz(opt.HealthzBindAddress, "volcano-scheduler", opt.CaCertData, opt.CertData, opt.KeyData); err != nil {
 			return err
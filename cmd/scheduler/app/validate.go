@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// ValidateConfigCommand is the subcommand of vc-scheduler validating scheduler configurations.
+const ValidateConfigCommand = "validate-config"
+
+// configMap is the part of a ConfigMap manifest holding the scheduler configuration.
+type configMap struct {
+	Kind string            `yaml:"kind"`
+	Data map[string]string `yaml:"data"`
+}
+
+// ValidateConfig validates the scheduler configuration files given in args and
+// writes the problems found to out. A file is either a scheduler configuration
+// or a ConfigMap manifest, in which case every data entry is validated.
+func ValidateConfig(args []string, out io.Writer) error {
+	fs := pflag.NewFlagSet(ValidateConfigCommand, pflag.ContinueOnError)
+	pluginsDir := fs.String("plugins-dir", "", "Load the custom plugins in this directory before validating")
+	fs.Usage = func() {
+		fmt.Fprintf(out, "Usage: vc-scheduler %s [--plugins-dir=<dir>] <file>...\n", ValidateConfigCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no scheduler configuration file given")
+	}
+
+	if len(*pluginsDir) != 0 {
+		if err := framework.LoadCustomPlugins(*pluginsDir); err != nil {
+			return fmt.Errorf("failed to load custom plugins: %v", err)
+		}
+	}
+
+	invalid := 0
+	for _, file := range fs.Args() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", file, err)
+		}
+
+		configs := map[string]string{file: string(data)}
+		cm := &configMap{}
+		if err := yaml.Unmarshal(data, cm); err == nil && cm.Kind == "ConfigMap" {
+			configs = map[string]string{}
+			for key, value := range cm.Data {
+				configs[fmt.Sprintf("%s[%s]", file, key)] = value
+			}
+		}
+
+		names := make([]string, 0, len(configs))
+		for name := range configs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			errs := scheduler.ValidateSchedulerConf(configs[name])
+			if len(errs) == 0 {
+				fmt.Fprintf(out, "%s: ok\n", name)
+				continue
+			}
+			invalid++
+			for _, err := range errs {
+				fmt.Fprintf(out, "%s: %v\n", name, err)
+			}
+		}
+	}
+
+	if invalid != 0 {
+		return fmt.Errorf("%d invalid scheduler configuration(s)", invalid)
+	}
+	return nil
+}
@@ -47,6 +47,14 @@ var logFlushFreq = pflag.Duration("log-flush-frequency", 5*time.Second, "Maximum
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
+	if len(os.Args) > 1 && os.Args[1] == app.ValidateConfigCommand {
+		if err := app.ValidateConfig(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	klog.InitFlags(nil)
 
 	fs := pflag.CommandLine
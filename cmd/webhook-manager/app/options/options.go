@@ -58,6 +58,10 @@ type Config struct {
 	// HealthzBindAddress is the IP address and port for the health check server to serve on
 	// defaulting to :11251
 	HealthzBindAddress string
+	// PluginsDir is the directory the custom job plugins are loaded from, so that the jobs using them are admitted.
+	PluginsDir string
+	// PluginWebhookConfig is the file of the configuration of the job plugins served by webhooks.
+	PluginWebhookConfig string
 }
 
 type DecryptFunc func(c *Config) error
@@ -91,6 +95,8 @@ func (c *Config) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.IgnoredNamespaces, "ignored-namespaces", defaultIgnoredNamespaces, "Comma-separated list of namespaces to be ignored by admission webhooks")
 	fs.BoolVar(&c.EnableHealthz, "enable-healthz", false, "Enable the health check; it is false by default")
 	fs.StringVar(&c.HealthzBindAddress, "healthz-address", defaultHealthzAddress, "The address to listen on for the health check server.")
+	fs.StringVar(&c.PluginsDir, "plugins-dir", "", "The directory of the custom job plugins loaded by vc-controller-manager")
+	fs.StringVar(&c.PluginWebhookConfig, "plugin-webhook-config", "", "The file of the configuration of the job plugins served by webhooks")
 }
 
 // CheckPortOrDie check valid port range.
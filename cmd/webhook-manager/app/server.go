@@ -32,6 +32,8 @@ import (
 	"volcano.sh/apis/pkg/apis/helpers"
 	"volcano.sh/apis/pkg/apis/scheduling/scheme"
 	"volcano.sh/volcano/cmd/webhook-manager/app/options"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/webhook"
 	"volcano.sh/volcano/pkg/kube"
 	commonutil "volcano.sh/volcano/pkg/util"
 	"volcano.sh/volcano/pkg/version"
@@ -61,6 +63,22 @@ func Run(config *options.Config) error {
 		return fmt.Errorf("unable to build k8s config: %v", err)
 	}
 
+	// The custom job plugins are registered so that the jobs using them are admitted.
+	if config.PluginsDir != "" {
+		if err := plugins.LoadCustomPlugins(config.PluginsDir); err != nil {
+			return fmt.Errorf("unable to load custom job plugins: %v", err)
+		}
+	}
+	if config.PluginWebhookConfig != "" {
+		conf, err := webhook.LoadConfiguration(config.PluginWebhookConfig)
+		if err != nil {
+			return err
+		}
+		if err := webhook.RegisterPlugins(conf); err != nil {
+			return fmt.Errorf("unable to register job plugins served by webhooks: %v", err)
+		}
+	}
+
 	admissionConf := wkconfig.LoadAdmissionConf(config.ConfigPath)
 	if admissionConf == nil {
 		klog.Errorf("loadAdmissionConf failed.")
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// The policies the pods are notified to checkpoint with.
+const (
+	// PolicySignal sends a signal to the process 1 of the container, the pods report themselves
+	// they checkpointed with the annotation volcano.sh/checkpoint-done.
+	PolicySignal = "signal"
+	// PolicyExec runs a shell command in the container, the pod checkpointed once it succeeded.
+	PolicyExec = "exec"
+	// PolicyHTTP posts to an endpoint of the pod, the pod checkpointed once it returned 2xx.
+	PolicyHTTP = "http"
+
+	// DefaultGracePeriod is the longest duration the evictions wait for the job to checkpoint by default.
+	DefaultGracePeriod = 5 * time.Minute
+	// DefaultSignal is the signal sent by default.
+	DefaultSignal = "SIGUSR1"
+)
+
+// Policy is the checkpoint policy of a job, read from its annotations.
+type Policy struct {
+	Type        string
+	GracePeriod time.Duration
+	Signal      string
+	Command     string
+	// Port and Path are the endpoint posted to.
+	Port      string
+	Path      string
+	Container string
+	// Tasks are the tasks whose pods are notified, all the tasks if empty.
+	Tasks map[string]bool
+}
+
+// GetPolicy returns the checkpoint policy of the job, nil if the job has none.
+func GetPolicy(job *batch.Job) (*Policy, error) {
+	annotations := job.Annotations
+	policyType, found := annotations[schedulingapi.CheckpointPolicyAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	policy := &Policy{
+		Type:        policyType,
+		GracePeriod: DefaultGracePeriod,
+		Signal:      DefaultSignal,
+		Container:   annotations[schedulingapi.CheckpointContainerAnnotation],
+	}
+	if value, found := annotations[schedulingapi.CheckpointGracePeriodAnnotation]; found {
+		gracePeriod, err := time.ParseDuration(value)
+		if err != nil || gracePeriod <= 0 {
+			return nil, fmt.Errorf("invalid checkpoint grace period %q, it must be a positive duration", value)
+		}
+		policy.GracePeriod = gracePeriod
+	}
+	if value, found := annotations[schedulingapi.CheckpointTasksAnnotation]; found {
+		policy.Tasks = map[string]bool{}
+		for _, task := range strings.Split(value, ",") {
+			if task = strings.TrimSpace(task); len(task) != 0 {
+				policy.Tasks[task] = true
+			}
+		}
+	}
+
+	switch policyType {
+	case PolicySignal:
+		if value, found := annotations[schedulingapi.CheckpointSignalAnnotation]; found {
+			policy.Signal = value
+		}
+		if len(strings.TrimPrefix(strings.ToUpper(policy.Signal), "SIG")) == 0 {
+			return nil, fmt.Errorf("invalid checkpoint signal %q", policy.Signal)
+		}
+	case PolicyExec:
+		policy.Command = annotations[schedulingapi.CheckpointCommandAnnotation]
+		if len(policy.Command) == 0 {
+			return nil, fmt.Errorf("checkpoint policy %s requires the annotation %s", policyType, schedulingapi.CheckpointCommandAnnotation)
+		}
+	case PolicyHTTP:
+		endpoint := annotations[schedulingapi.CheckpointEndpointAnnotation]
+		port, path, _ := strings.Cut(strings.TrimPrefix(endpoint, ":"), "/")
+		if len(port) == 0 {
+			return nil, fmt.Errorf("checkpoint policy %s requires the annotation %s, e.g. :8080/checkpoint", policyType, schedulingapi.CheckpointEndpointAnnotation)
+		}
+		policy.Port, policy.Path = port, "/"+path
+	default:
+		return nil, fmt.Errorf("unknown checkpoint policy %q, valid policies are %s, %s and %s", policyType, PolicySignal, PolicyExec, PolicyHTTP)
+	}
+	return policy, nil
+}
+
+// Notified returns whether the pod is notified to checkpoint with the job.
+func (p *Policy) Notified(pod *v1.Pod) bool {
+	return len(p.Tasks) == 0 || p.Tasks[pod.Annotations[batch.TaskSpecKey]]
+}
+
+// Notify notifies the pod to checkpoint, and returns whether it checkpointed once notified.
+// The pods notified with a signal report themselves they checkpointed.
+func (p *Policy) Notify(ctx context.Context, notifier Notifier, pod *v1.Pod) (bool, error) {
+	container := p.Container
+	if len(container) == 0 && len(pod.Spec.Containers) != 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	switch p.Type {
+	case PolicySignal:
+		signal := strings.TrimPrefix(strings.ToUpper(p.Signal), "SIG")
+		return false, notifier.Exec(ctx, pod, container, []string{"kill", "-" + signal, "1"})
+	case PolicyExec:
+		if err := notifier.Exec(ctx, pod, container, []string{"/bin/sh", "-c", p.Command}); err != nil {
+			return false, err
+		}
+		return true, nil
+	case PolicyHTTP:
+		if len(pod.Status.PodIP) == 0 {
+			return false, fmt.Errorf("pod %s/%s has no IP", pod.Namespace, pod.Name)
+		}
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, p.Port), p.Path)
+		if err := notifier.Post(ctx, url); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown checkpoint policy %q", p.Type)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+type fakeNotifier struct {
+	container string
+	command   []string
+	url       string
+}
+
+func (n *fakeNotifier) Exec(ctx context.Context, pod *v1.Pod, container string, command []string) error {
+	n.container, n.command = container, command
+	return nil
+}
+
+func (n *fakeNotifier) Post(ctx context.Context, url string) error {
+	n.url = url
+	return nil
+}
+
+func TestGetPolicy(t *testing.T) {
+	testcases := []struct {
+		Name        string
+		Annotations map[string]string
+		Policy      *Policy
+		Error       bool
+	}{
+		{
+			Name:        "job without checkpoint policy",
+			Annotations: map[string]string{},
+		},
+		{
+			Name: "signal policy with defaults",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointPolicyAnnotation: PolicySignal,
+			},
+			Policy: &Policy{Type: PolicySignal, GracePeriod: DefaultGracePeriod, Signal: DefaultSignal},
+		},
+		{
+			Name: "http policy of some tasks",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointPolicyAnnotation:      PolicyHTTP,
+				schedulingapi.CheckpointEndpointAnnotation:    ":8080/checkpoint",
+				schedulingapi.CheckpointGracePeriodAnnotation: "30s",
+				schedulingapi.CheckpointTasksAnnotation:       "master, worker",
+			},
+			Policy: &Policy{
+				Type:        PolicyHTTP,
+				GracePeriod: 30 * time.Second,
+				Signal:      DefaultSignal,
+				Port:        "8080",
+				Path:        "/checkpoint",
+				Tasks:       map[string]bool{"master": true, "worker": true},
+			},
+		},
+		{
+			Name: "exec policy without command",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointPolicyAnnotation: PolicyExec,
+			},
+			Error: true,
+		},
+		{
+			Name: "invalid grace period",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointPolicyAnnotation:      PolicySignal,
+				schedulingapi.CheckpointGracePeriodAnnotation: "-1m",
+			},
+			Error: true,
+		},
+		{
+			Name: "unknown policy",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointPolicyAnnotation: "snapshot",
+			},
+			Error: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Annotations: testcase.Annotations}}
+			policy, err := GetPolicy(job)
+			if (err != nil) != testcase.Error {
+				t.Fatalf("expected error %v, got %v", testcase.Error, err)
+			}
+			if !reflect.DeepEqual(policy, testcase.Policy) {
+				t.Errorf("expected policy %+v, got %+v", testcase.Policy, policy)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1-worker-0", Namespace: "test"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "worker"}, {Name: "sidecar"}},
+		},
+		Status: v1.PodStatus{PodIP: "10.0.0.1"},
+	}
+
+	testcases := []struct {
+		Name     string
+		Policy   *Policy
+		Expected fakeNotifier
+		Done     bool
+	}{
+		{
+			Name:     "signal sent to the first container",
+			Policy:   &Policy{Type: PolicySignal, Signal: "SIGTERM"},
+			Expected: fakeNotifier{container: "worker", command: []string{"kill", "-TERM", "1"}},
+		},
+		{
+			Name:     "command run in the container",
+			Policy:   &Policy{Type: PolicyExec, Command: "touch /tmp/checkpoint", Container: "sidecar"},
+			Expected: fakeNotifier{container: "sidecar", command: []string{"/bin/sh", "-c", "touch /tmp/checkpoint"}},
+			Done:     true,
+		},
+		{
+			Name:     "endpoint of the pod posted to",
+			Policy:   &Policy{Type: PolicyHTTP, Port: "8080", Path: "/checkpoint"},
+			Expected: fakeNotifier{url: "http://10.0.0.1:8080/checkpoint"},
+			Done:     true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			notifier := &fakeNotifier{}
+			done, err := testcase.Policy.Notify(context.TODO(), notifier, pod)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if done != testcase.Done {
+				t.Errorf("expected done %v, got %v", testcase.Done, done)
+			}
+			if !reflect.DeepEqual(*notifier, testcase.Expected) {
+				t.Errorf("expected notification %+v, got %+v", testcase.Expected, *notifier)
+			}
+		})
+	}
+}
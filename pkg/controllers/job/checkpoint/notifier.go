@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Notifier runs the notifications of the pods.
+type Notifier interface {
+	// Exec runs the command in the container of the pod, it fails if the command does.
+	Exec(ctx context.Context, pod *v1.Pod, container string, command []string) error
+	// Post posts to the url, it fails unless the response is 2xx.
+	Post(ctx context.Context, url string) error
+}
+
+type notifier struct {
+	kubeClient kubernetes.Interface
+	config     *rest.Config
+	client     *http.Client
+}
+
+// NewNotifier creates a notifier calling the pods, and the API server at config to exec in them.
+func NewNotifier(kubeClient kubernetes.Interface, config *rest.Config) Notifier {
+	return &notifier{kubeClient: kubeClient, config: config, client: &http.Client{}}
+}
+
+// Exec runs the command through the exec subresource of the pod, as kubectl exec does.
+func (n *notifier) Exec(ctx context.Context, pod *v1.Pod, container string, command []string) error {
+	if n.config == nil {
+		return fmt.Errorf("no config of the API server to exec in pod %s/%s", pod.Namespace, pod.Name)
+	}
+
+	req := n.kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(n.config, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return fmt.Errorf("exec in pod %s/%s failed: %v: %s", pod.Namespace, pod.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (n *notifier) Post(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
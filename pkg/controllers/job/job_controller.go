@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -52,6 +53,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobcache "volcano.sh/volcano/pkg/controllers/cache"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/job/checkpoint"
 	"volcano.sh/volcano/pkg/controllers/job/state"
 	"volcano.sh/volcano/pkg/features"
 )
@@ -115,6 +117,11 @@ type jobcontroller struct {
 	errTasks      workqueue.RateLimitingInterface
 	workers       uint32
 	maxRequeueNum int
+
+	// checkpointNotifier notifies the pods of the jobs with a checkpoint policy before they are evicted
+	checkpointNotifier checkpoint.Notifier
+	// checkpointRounds are the rounds of checkpoint the pods were notified of, by pod UID
+	checkpointRounds sync.Map
 }
 
 func (cc *jobcontroller) Name() string {
@@ -125,6 +132,7 @@ func (cc *jobcontroller) Name() string {
 func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
 	cc.kubeClient = opt.KubeClient
 	cc.vcClient = opt.VolcanoClient
+	cc.checkpointNotifier = checkpoint.NewNotifier(opt.KubeClient, opt.KubeConfig)
 
 	sharedInformers := opt.SharedInformerFactory
 	workers := opt.WorkerNum
@@ -229,6 +237,7 @@ func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
 	// Register actions
 	state.SyncJob = cc.syncJob
 	state.KillJob = cc.killJob
+	state.CheckpointJob = cc.checkpointJob
 
 	return nil
 }
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/checkpoint"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// checkpointJob notifies the pods of the job to checkpoint once the scheduler requested to evict some of them,
+// and evicts those once the notified pods checkpointed or the grace period of the job expired. The pods requested
+// the earliest start the round of checkpoint, the grace period runs from then.
+func (cc *jobcontroller) checkpointJob(jobInfo *apis.JobInfo) error {
+	job := jobInfo.Job
+	var requested, running []*v1.Pod
+	round := ""
+	for _, pods := range jobInfo.Pods {
+		for _, pod := range pods {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			if requestedAt := pod.Annotations[schedulingapi.CheckpointRequestedAnnotation]; len(requestedAt) != 0 {
+				requested = append(requested, pod)
+				if len(round) == 0 || requestedAt < round {
+					round = requestedAt
+				}
+			}
+			if pod.Status.Phase == v1.PodRunning {
+				running = append(running, pod)
+			}
+		}
+	}
+	if len(requested) == 0 {
+		return nil
+	}
+
+	policy, err := checkpoint.GetPolicy(job)
+	if err != nil || policy == nil {
+		klog.Warningf("Evicting pods of Job <%s/%s> without checkpoint, its checkpoint policy is removed or invalid: %v",
+			job.Namespace, job.Name, err)
+		return cc.evictCheckpointedPods(job, requested, "the job has no valid checkpoint policy")
+	}
+	requestedAt, err := time.Parse(time.RFC3339, round)
+	if err != nil {
+		klog.Warningf("Evicting pods of Job <%s/%s> without checkpoint, invalid request time %q: %v", job.Namespace, job.Name, round, err)
+		return cc.evictCheckpointedPods(job, requested, "the checkpoint request is invalid")
+	}
+	deadline := requestedAt.Add(policy.GracePeriod)
+
+	checkpointed := true
+	for _, pod := range running {
+		if !policy.Notified(pod) {
+			continue
+		}
+		if !cc.checkpointNotified(pod, round) {
+			if err := cc.notifyCheckpoint(pod, policy, round, deadline); err != nil {
+				return err
+			}
+			checkpointed = false
+			continue
+		}
+		if _, found := pod.Annotations[schedulingapi.CheckpointDoneAnnotation]; !found {
+			checkpointed = false
+		}
+	}
+
+	if checkpointed {
+		return cc.evictCheckpointedPods(job, requested, "the job checkpointed")
+	}
+	if wait := time.Until(deadline); wait > 0 {
+		klog.V(3).Infof("Waiting up to %v for Job <%s/%s> to checkpoint before evicting %d pods",
+			wait, job.Namespace, job.Name, len(requested))
+		req := apis.Request{
+			Namespace:  job.Namespace,
+			JobName:    job.Name,
+			Action:     state.CheckpointJobAction,
+			JobVersion: job.Status.Version,
+		}
+		cc.getWorkerQueue(jobhelpers.GetJobKeyByReq(&req)).AddAfter(req, wait)
+		return nil
+	}
+	return cc.evictCheckpointedPods(job, requested, fmt.Sprintf("the checkpoint grace period %v expired", policy.GracePeriod))
+}
+
+// checkpointNotified returns whether the pod was notified of the round of checkpoint, the pods
+// notified are recorded ahead of the cache so that they are not notified twice.
+func (cc *jobcontroller) checkpointNotified(pod *v1.Pod, round string) bool {
+	if pod.Annotations[schedulingapi.CheckpointNotifiedAnnotation] >= round {
+		return true
+	}
+	notified, found := cc.checkpointRounds.Load(pod.UID)
+	return found && notified.(string) >= round
+}
+
+// notifyCheckpoint notifies the pod to checkpoint in the background, the pod is annotated once it
+// checkpointed if the notification tells so.
+func (cc *jobcontroller) notifyCheckpoint(pod *v1.Pod, policy *checkpoint.Policy, round string, deadline time.Time) error {
+	if err := cc.patchPodAnnotations(pod, map[string]interface{}{
+		schedulingapi.CheckpointNotifiedAnnotation: round,
+		schedulingapi.CheckpointDoneAnnotation:     nil,
+	}); err != nil {
+		return err
+	}
+	cc.checkpointRounds.Store(pod.UID, round)
+	cc.recorder.Eventf(pod, v1.EventTypeNormal, "Checkpoint", "Pod is notified to checkpoint with %s before eviction", policy.Type)
+
+	go func() {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		done, err := policy.Notify(ctx, cc.checkpointNotifier, pod)
+		if err != nil {
+			klog.Errorf("Failed to notify pod <%s/%s> to checkpoint: %v", pod.Namespace, pod.Name, err)
+			cc.recorder.Eventf(pod, v1.EventTypeWarning, "CheckpointFailed", "Failed to notify pod to checkpoint: %v", err)
+			return
+		}
+		if !done {
+			return
+		}
+		if err := cc.patchPodAnnotations(pod, map[string]interface{}{
+			schedulingapi.CheckpointDoneAnnotation: time.Now().UTC().Format(time.RFC3339),
+		}); err != nil {
+			klog.Errorf("Failed to annotate pod <%s/%s> checkpointed: %v", pod.Namespace, pod.Name, err)
+		}
+	}()
+	return nil
+}
+
+// evictCheckpointedPods evicts the pods requested to once their job checkpointed, the policies
+// of the job are applied on the eviction of the pods as on the evictions of the scheduler.
+func (cc *jobcontroller) evictCheckpointedPods(job *batch.Job, pods []*v1.Pod, reason string) error {
+	var errs []error
+	for _, pod := range pods {
+		klog.V(3).Infof("Evicting pod <%s/%s>, because %s", pod.Namespace, pod.Name, reason)
+		cc.recorder.Eventf(pod, v1.EventTypeWarning, "Evict", "Pod is evicted, because %s", reason)
+		if err := cc.deleteJobPod(job.Name, pod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("failed to evict %d pods of %d: %v", len(errs), len(pods), errs)
+	}
+	return nil
+}
+
+// patchPodAnnotations sets the annotations of the pod, the annotations set to nil are removed.
+func (cc *jobcontroller) patchPodAnnotations(pod *v1.Pod, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := cc.kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.Errorf("Failed to patch annotations of pod <%s/%s>: %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+	return nil
+}
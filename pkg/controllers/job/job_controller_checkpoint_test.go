@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+type fakeCheckpointNotifier struct {
+	mutex sync.Mutex
+	execs map[string][]string
+}
+
+func (n *fakeCheckpointNotifier) Exec(ctx context.Context, pod *v1.Pod, container string, command []string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.execs[pod.Name] = command
+	return nil
+}
+
+func (n *fakeCheckpointNotifier) Post(ctx context.Context, url string) error {
+	return nil
+}
+
+func (n *fakeCheckpointNotifier) executed(pod string) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	_, found := n.execs[pod]
+	return found
+}
+
+func TestCheckpointJob(t *testing.T) {
+	namespace := "test"
+	now := time.Now().UTC()
+	round := now.Format(time.RFC3339)
+	expiredRound := now.Add(-10 * time.Minute).Format(time.RFC3339)
+
+	buildPod := func(name string, annotations map[string]string) *v1.Pod {
+		annotations[batch.TaskSpecKey] = "worker"
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				UID:         types.UID("uid-" + name),
+				Annotations: annotations,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "worker"}},
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+			},
+		}
+	}
+
+	testcases := []struct {
+		Name     string
+		Pods     []*v1.Pod
+		Notified []string
+		Evicted  []string
+	}{
+		{
+			Name: "the pods of the job are notified when one of them is requested to be evicted",
+			Pods: []*v1.Pod{
+				buildPod("job1-worker-0", map[string]string{schedulingapi.CheckpointRequestedAnnotation: round}),
+				buildPod("job1-worker-1", map[string]string{}),
+			},
+			Notified: []string{"job1-worker-0", "job1-worker-1"},
+		},
+		{
+			Name: "the pod is evicted once the pods of the job checkpointed",
+			Pods: []*v1.Pod{
+				buildPod("job1-worker-0", map[string]string{
+					schedulingapi.CheckpointRequestedAnnotation: round,
+					schedulingapi.CheckpointNotifiedAnnotation:  round,
+					schedulingapi.CheckpointDoneAnnotation:      round,
+				}),
+				buildPod("job1-worker-1", map[string]string{
+					schedulingapi.CheckpointNotifiedAnnotation: round,
+					schedulingapi.CheckpointDoneAnnotation:     round,
+				}),
+			},
+			Evicted: []string{"job1-worker-0"},
+		},
+		{
+			Name: "the pod is evicted once the grace period expired",
+			Pods: []*v1.Pod{
+				buildPod("job1-worker-0", map[string]string{
+					schedulingapi.CheckpointRequestedAnnotation: expiredRound,
+					schedulingapi.CheckpointNotifiedAnnotation:  expiredRound,
+				}),
+				buildPod("job1-worker-1", map[string]string{
+					schedulingapi.CheckpointNotifiedAnnotation: expiredRound,
+					schedulingapi.CheckpointDoneAnnotation:     expiredRound,
+				}),
+			},
+			Evicted: []string{"job1-worker-0"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			fakeController := newFakeController()
+			notifier := &fakeCheckpointNotifier{execs: map[string][]string{}}
+			fakeController.checkpointNotifier = notifier
+
+			job := &batch.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						schedulingapi.CheckpointPolicyAnnotation:      "exec",
+						schedulingapi.CheckpointCommandAnnotation:     "touch /tmp/checkpoint",
+						schedulingapi.CheckpointGracePeriodAnnotation: "5m",
+					},
+				},
+			}
+			jobInfo := &apis.JobInfo{
+				Namespace: namespace,
+				Name:      job.Name,
+				Job:       job,
+				Pods:      map[string]map[string]*v1.Pod{"worker": {}},
+			}
+			for _, pod := range testcase.Pods {
+				if _, err := fakeController.kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("failed to create pod %s: %v", pod.Name, err)
+				}
+				jobInfo.Pods["worker"][pod.Name] = pod
+			}
+
+			if err := fakeController.checkpointJob(jobInfo); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, name := range testcase.Notified {
+				// The pods are notified in the background, and annotated once the command succeeded.
+				err := wait.PollImmediate(10*time.Millisecond, 3*time.Second, func() (bool, error) {
+					pod, err := fakeController.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+					if err != nil {
+						return false, err
+					}
+					_, done := pod.Annotations[schedulingapi.CheckpointDoneAnnotation]
+					return notifier.executed(name) && done &&
+						pod.Annotations[schedulingapi.CheckpointNotifiedAnnotation] == round, nil
+				})
+				if err != nil {
+					t.Errorf("expected pod %s to be notified and checkpointed: %v", name, err)
+				}
+			}
+
+			evicted := map[string]bool{}
+			for _, name := range testcase.Evicted {
+				evicted[name] = true
+			}
+			for _, pod := range testcase.Pods {
+				_, err := fakeController.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+				if deleted := apierrors.IsNotFound(err); deleted != evicted[pod.Name] {
+					t.Errorf("expected pod %s evicted %v, got %v", pod.Name, evicted[pod.Name], deleted)
+				}
+			}
+		})
+	}
+}
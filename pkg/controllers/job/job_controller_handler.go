@@ -35,6 +35,9 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobcache "volcano.sh/volcano/pkg/controllers/cache"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func (cc *jobcontroller) addCommand(obj interface{}) {
@@ -281,6 +284,12 @@ func (cc *jobcontroller) updatePod(oldObj, newObj interface{}) {
 		ExitCode:   exitCode,
 		JobVersion: int32(dVersion),
 	}
+	// The pods requested to be evicted once their job checkpointed, and the pods reporting they
+	// checkpointed, drive the checkpoint of the job.
+	if event == bus.OutOfSyncEvent && (len(newPod.Annotations[schedulingapi.CheckpointRequestedAnnotation]) != 0 ||
+		newPod.Annotations[schedulingapi.CheckpointDoneAnnotation] != oldPod.Annotations[schedulingapi.CheckpointDoneAnnotation]) {
+		req.Action = state.CheckpointJobAction
+	}
 
 	key := jobhelpers.GetJobKeyByReq(&req)
 	queue := cc.getWorkerQueue(key)
@@ -344,6 +353,12 @@ func (cc *jobcontroller) deletePod(obj interface{}) {
 		Event:      bus.PodEvictedEvent,
 		JobVersion: int32(dVersion),
 	}
+	if cc.scaledDown(pod, jobName, taskName) {
+		// The other pods of elastic jobs keep running, the policies of the eviction are not applied.
+		req.Event = bus.OutOfSyncEvent
+	}
+
+	cc.checkpointRounds.Delete(pod.UID)
 
 	if err := cc.cache.DeletePod(pod); err != nil {
 		klog.Errorf("Failed to delete Pod <%s/%s>: %v in cache",
@@ -355,6 +370,20 @@ func (cc *jobcontroller) deletePod(obj interface{}) {
 	queue.Add(req)
 }
 
+// scaledDown returns whether the pod was deleted as the task of its elastic job was scaled down.
+func (cc *jobcontroller) scaledDown(pod *v1.Pod, jobName, taskName string) bool {
+	jobInfo, err := cc.cache.Get(jobcache.JobKeyByName(pod.Namespace, jobName))
+	if err != nil || jobInfo.Job == nil || !pytorch.Elastic(jobInfo.Job) {
+		return false
+	}
+	index, err := strconv.Atoi(jobhelpers.GetPodIndexUnderTask(pod))
+	if err != nil {
+		return false
+	}
+	taskIndex := jobhelpers.GetTaskIndexUnderJob(taskName, jobInfo.Job)
+	return taskIndex != -1 && int32(index) >= jobInfo.Job.Spec.Tasks[taskIndex].Replicas
+}
+
 func (cc *jobcontroller) recordJobEvent(namespace, name string, event batch.JobEvent, message string) {
 	job, err := cc.cache.Get(jobcache.JobKeyByName(namespace, name))
 	if err != nil {
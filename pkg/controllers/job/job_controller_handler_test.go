@@ -34,6 +34,7 @@ import (
 	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/framework"
 )
 
@@ -518,6 +519,83 @@ func TestDeletePodFunc(t *testing.T) {
 	}
 }
 
+func TestDeletePodOfElasticJob(t *testing.T) {
+	namespace := "test"
+
+	testcases := []struct {
+		Name          string
+		Plugins       map[string][]string
+		deletePod     string
+		ExpectedEvent bus.Event
+	}{
+		{
+			Name:          "scaled down pod of elastic job",
+			Plugins:       map[string][]string{"pytorch": {"--elastic"}},
+			deletePod:     "job1-worker-1",
+			ExpectedEvent: bus.OutOfSyncEvent,
+		},
+		{
+			Name:          "evicted pod of elastic job",
+			Plugins:       map[string][]string{"pytorch": {"--elastic"}},
+			deletePod:     "job1-worker-0",
+			ExpectedEvent: bus.PodEvictedEvent,
+		},
+		{
+			Name:          "scaled down pod of static job",
+			Plugins:       map[string][]string{"pytorch": {}},
+			deletePod:     "job1-worker-1",
+			ExpectedEvent: bus.PodEvictedEvent,
+		},
+	}
+
+	for i, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			controller := newController()
+			job := &batch.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: batch.JobSpec{
+					Plugins: testcase.Plugins,
+					Tasks: []batch.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 1,
+						},
+					},
+				},
+			}
+			controller.addJob(job)
+			annotations := map[string]string{
+				batch.JobNameKey:  "job1",
+				batch.JobVersion:  "0",
+				batch.TaskSpecKey: "worker",
+			}
+			for _, name := range []string{"job1-worker-0", "job1-worker-1"} {
+				controller.addPod(addPodAnnotation(buildPod(namespace, name, v1.PodRunning, nil), annotations))
+			}
+
+			key := fmt.Sprintf("%s/%s", namespace, "job1")
+			queue := controller.getWorkerQueue(key)
+			for queue.Len() != 0 {
+				item, _ := queue.Get()
+				queue.Done(item)
+				queue.Forget(item)
+			}
+
+			controller.deletePod(addPodAnnotation(buildPod(namespace, testcase.deletePod, v1.PodRunning, nil), annotations))
+			if queue.Len() != 1 {
+				t.Fatalf("case %d (%s): expected 1 request, got %d", i, testcase.Name, queue.Len())
+			}
+			item, _ := queue.Get()
+			if req := item.(apis.Request); req.Event != testcase.ExpectedEvent {
+				t.Errorf("case %d (%s): expected event %v, got %v", i, testcase.Name, testcase.ExpectedEvent, req.Event)
+			}
+		})
+	}
+}
+
 func TestUpdatePodGroupFunc(t *testing.T) {
 
 	namespace := "test"
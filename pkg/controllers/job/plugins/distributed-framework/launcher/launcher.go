@@ -0,0 +1,397 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package launcher
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// LauncherPluginName is the name of the plugin
+	LauncherPluginName = "launcher"
+	// DefaultLauncher is the default task name of the launcher
+	DefaultLauncher = "launcher"
+	// DefaultWorker is the default task name of the workers
+	DefaultWorker = "worker"
+	// DefaultHostfileFormat is the default format of a line of the hostfile
+	DefaultHostfileFormat = "{{.Host}} slots={{.Slots}}"
+	// DefaultHostsFormat is the default format of a host in the host list
+	DefaultHostsFormat = "{{.Host}}"
+	// DefaultMountPath is the default directory the hostfile is mounted in
+	DefaultMountPath = "/etc/volcano/launcher"
+	// DefaultHostfileName is the default name of the hostfile
+	DefaultHostfileName = "hostfile"
+	// GPUResourceName is the resource the slots are counted in when the workers request it
+	GPUResourceName = "nvidia.com/gpu"
+
+	// EnvHostfile is the env name of the path of the hostfile
+	EnvHostfile = "LAUNCHER_HOSTFILE"
+	// EnvHosts is the env name of the comma separated host list
+	EnvHosts = "LAUNCHER_HOSTS"
+	// EnvNumHosts is the env name of the number of hosts
+	EnvNumHosts = "LAUNCHER_NUM_HOSTS"
+	// EnvNumSlots is the env name of the total number of slots
+	EnvNumSlots = "LAUNCHER_NUM_SLOTS"
+
+	// The keys of the ConfigMap the envs are read from.
+	hostsKey    = "hosts"
+	numHostsKey = "num_hosts"
+	numSlotsKey = "num_slots"
+)
+
+// preset is the hostfile format and the envs a launcher expects.
+type preset struct {
+	hostfileFormat string
+	hostsFormat    string
+	mountPath      string
+	// hostsEnvs and numHostsEnvs are set to the host list and the number of hosts
+	// in addition to EnvHosts and EnvNumHosts.
+	hostsEnvs    []string
+	numHostsEnvs []string
+}
+
+var presets = map[string]preset{
+	// deepspeed --hostfile=/job/hostfile, which is also the default hostfile of DeepSpeed.
+	"deepspeed": {
+		hostfileFormat: "{{.Host}} slots={{.Slots}}",
+		hostsFormat:    "{{.Host}}",
+		mountPath:      "/job",
+	},
+	// horovodrun -np $LAUNCHER_NUM_SLOTS -H $LAUNCHER_HOSTS, or --hostfile=$LAUNCHER_HOSTFILE.
+	"horovod": {
+		hostfileFormat: "{{.Host}} slots={{.Slots}}",
+		hostsFormat:    "{{.Host}}:{{.Slots}}",
+	},
+	// python -m paddle.distributed.launch --ips=$PADDLE_TRAINERS.
+	"paddle": {
+		hostfileFormat: "{{.Host}}",
+		hostsFormat:    "{{.Host}}",
+		hostsEnvs:      []string{"PADDLE_TRAINERS"},
+		numHostsEnvs:   []string{"PADDLE_TRAINERS_NUM"},
+	},
+}
+
+// host is the data the formats of the hostfile and the host list are rendered with.
+type host struct {
+	Host  string
+	Index int
+	Slots int
+}
+
+// Plugin renders the hosts of the worker task into a hostfile mounted into the
+// launcher task, for the launchers which start the workers over ssh or rpc.
+type Plugin struct {
+	launcherArguments []string
+	clientset         pluginsinterface.PluginClientset
+
+	presetName     string
+	launcherName   string
+	workerName     string
+	hostfileFormat string
+	hostsFormat    string
+	mountPath      string
+	hostfileName   string
+	slotsResource  string
+	slots          int
+	preset         preset
+}
+
+// New creates launcher plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	lp := Plugin{launcherArguments: arguments, clientset: client}
+	lp.addFlags()
+	return &lp
+}
+
+// NewInstance creates launcher plugin without clientset, e.g. to validate jobs.
+func NewInstance(arguments []string) Plugin {
+	lp := Plugin{launcherArguments: arguments}
+	lp.addFlags()
+	return lp
+}
+
+func (lp *Plugin) addFlags() {
+	flagSet := flag.NewFlagSet(lp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&lp.presetName, "preset", "", "preset of the launcher: deepspeed, horovod or paddle")
+	flagSet.StringVar(&lp.launcherName, "launcher", DefaultLauncher, "name of launcher role task")
+	flagSet.StringVar(&lp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.StringVar(&lp.hostfileFormat, "hostfile-format", "", "format of a line of the hostfile, with the fields Host, Index and Slots")
+	flagSet.StringVar(&lp.hostsFormat, "hosts-format", "", "format of a host in the host list, with the fields Host, Index and Slots")
+	flagSet.StringVar(&lp.mountPath, "mount-path", "", "directory the hostfile is mounted in")
+	flagSet.StringVar(&lp.hostfileName, "hostfile-name", DefaultHostfileName, "name of the hostfile")
+	flagSet.StringVar(&lp.slotsResource, "slots-resource", "", "resource the slots of a worker are counted in, nvidia.com/gpu if the workers request it and cpu otherwise by default")
+	flagSet.IntVar(&lp.slots, "slots", 0, "slots of a worker, counted from the requests of the workers by default")
+	if err := flagSet.Parse(lp.launcherArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", lp.Name(), err)
+	}
+
+	if len(lp.presetName) != 0 {
+		p, found := presets[lp.presetName]
+		if !found {
+			klog.Errorf("plugin %s has no preset %s", lp.Name(), lp.presetName)
+		}
+		lp.preset = p
+	}
+	if len(lp.hostfileFormat) == 0 {
+		lp.hostfileFormat = defaultString(lp.preset.hostfileFormat, DefaultHostfileFormat)
+	}
+	if len(lp.hostsFormat) == 0 {
+		lp.hostsFormat = defaultString(lp.preset.hostsFormat, DefaultHostsFormat)
+	}
+	if len(lp.mountPath) == 0 {
+		lp.mountPath = defaultString(lp.preset.mountPath, DefaultMountPath)
+	}
+}
+
+func defaultString(value, defaultValue string) string {
+	if len(value) != 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func (lp *Plugin) Name() string {
+	return LauncherPluginName
+}
+
+func (lp *Plugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	cmName := lp.cmName(job)
+	envs := []v1.EnvVar{
+		{
+			Name:  EnvHostfile,
+			Value: path.Join(lp.mountPath, lp.hostfileName),
+		},
+	}
+	addEnvs := func(key string, names ...string) {
+		for _, name := range names {
+			envs = append(envs, v1.EnvVar{
+				Name: name,
+				ValueFrom: &v1.EnvVarSource{
+					ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: cmName},
+						Key:                  key,
+					},
+				},
+			})
+		}
+	}
+	addEnvs(hostsKey, append([]string{EnvHosts}, lp.preset.hostsEnvs...)...)
+	addEnvs(numHostsKey, append([]string{EnvNumHosts}, lp.preset.numHostsEnvs...)...)
+	addEnvs(numSlotsKey, EnvNumSlots)
+
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envs...)
+	}
+
+	if jobhelpers.GetTaskKey(pod) != lp.launcherName {
+		return nil
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: cmName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: cmName},
+				Items: []v1.KeyToPath{
+					{
+						Key:  lp.hostfileName,
+						Path: lp.hostfileName,
+					},
+				},
+			},
+		},
+	})
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, v1.VolumeMount{
+			Name:      cmName,
+			MountPath: lp.mountPath,
+		})
+	}
+
+	return nil
+}
+
+// GenerateHostfile renders the hostfile and the host list of the worker task.
+func (lp *Plugin) GenerateHostfile(job *batch.Job) (map[string]string, error) {
+	hostfileTemplate, err := template.New("hostfile").Parse(lp.hostfileFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hostfile format %q: %v", lp.hostfileFormat, err)
+	}
+	hostsTemplate, err := template.New("hosts").Parse(lp.hostsFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hosts format %q: %v", lp.hostsFormat, err)
+	}
+
+	var lines, hosts []string
+	numSlots := 0
+	if index := jobhelpers.GetTaskIndexUnderJob(lp.workerName, job); index != -1 {
+		ts := job.Spec.Tasks[index]
+		slots := lp.getSlots(ts)
+		for i := 0; i < int(ts.Replicas); i++ {
+			h := host{Host: jobhelpers.MakeDomainName(ts, job, i), Index: i, Slots: slots}
+			line := &bytes.Buffer{}
+			if err := hostfileTemplate.Execute(line, h); err != nil {
+				return nil, fmt.Errorf("failed to render hostfile format %q: %v", lp.hostfileFormat, err)
+			}
+			lines = append(lines, line.String())
+			entry := &bytes.Buffer{}
+			if err := hostsTemplate.Execute(entry, h); err != nil {
+				return nil, fmt.Errorf("failed to render hosts format %q: %v", lp.hostsFormat, err)
+			}
+			hosts = append(hosts, entry.String())
+			numSlots += slots
+		}
+	}
+
+	return map[string]string{
+		lp.hostfileName: strings.Join(lines, "\n") + "\n",
+		hostsKey:        strings.Join(hosts, ","),
+		numHostsKey:     strconv.Itoa(len(hosts)),
+		numSlotsKey:     strconv.Itoa(numSlots),
+	}, nil
+}
+
+// getSlots returns the slots of a pod of the task: the GPUs it requests, or
+// its CPUs if it requests none, at least one.
+func (lp *Plugin) getSlots(ts batch.TaskSpec) int {
+	if lp.slots > 0 {
+		return lp.slots
+	}
+
+	requests := v1.ResourceList{}
+	for _, c := range ts.Template.Spec.Containers {
+		for name, quantity := range c.Resources.Requests {
+			if value, found := requests[name]; found {
+				value.Add(quantity)
+				requests[name] = value
+			} else {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+		// Extended resources such as GPUs may only be set in limits.
+		for name, quantity := range c.Resources.Limits {
+			if _, found := c.Resources.Requests[name]; found {
+				continue
+			}
+			if value, found := requests[name]; found {
+				value.Add(quantity)
+				requests[name] = value
+			} else {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	resource := v1.ResourceName(lp.slotsResource)
+	if len(resource) == 0 {
+		resource = v1.ResourceCPU
+		if gpus, found := requests[GPUResourceName]; found && !gpus.IsZero() {
+			resource = GPUResourceName
+		}
+	}
+
+	slots := 0
+	if quantity, found := requests[resource]; found {
+		slots = int(quantity.Value())
+		if resource == v1.ResourceCPU {
+			// Fractional CPUs do not make a slot.
+			slots = int(quantity.MilliValue() / 1000)
+		}
+	}
+	if slots < 1 {
+		slots = 1
+	}
+	return slots
+}
+
+func (lp *Plugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+lp.Name()] == lp.Name() {
+		return nil
+	}
+
+	if err := lp.createOrUpdateHostfile(job); err != nil {
+		return err
+	}
+	job.Status.ControlledResources["plugin-"+lp.Name()] = lp.Name()
+	return nil
+}
+
+func (lp *Plugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+lp.Name()] != lp.Name() {
+		return nil
+	}
+
+	if err := helpers.DeleteConfigmap(job, lp.clientset.KubeClients, lp.cmName(job)); err != nil {
+		return err
+	}
+	delete(job.Status.ControlledResources, "plugin-"+lp.Name())
+	return nil
+}
+
+// OnJobUpdate updates the hostfile as the worker task is scaled.
+func (lp *Plugin) OnJobUpdate(job *batch.Job) error {
+	return lp.createOrUpdateHostfile(job)
+}
+
+func (lp *Plugin) createOrUpdateHostfile(job *batch.Job) error {
+	data, err := lp.GenerateHostfile(job)
+	if err != nil {
+		return err
+	}
+	return helpers.CreateOrUpdateConfigMap(job, lp.clientset.KubeClients, data, lp.cmName(job))
+}
+
+func (lp *Plugin) cmName(job *batch.Job) string {
+	return fmt.Sprintf("%s-%s", job.Name, lp.Name())
+}
+
+func (lp *Plugin) GetLauncherName() string {
+	return lp.launcherName
+}
+
+func (lp *Plugin) GetWorkerName() string {
+	return lp.workerName
+}
+
+// Validate checks the preset and the formats of the hostfile and the host list.
+func (lp *Plugin) Validate() error {
+	if len(lp.presetName) != 0 {
+		if _, found := presets[lp.presetName]; !found {
+			return fmt.Errorf("unknown launcher preset %s", lp.presetName)
+		}
+	}
+	if _, err := template.New("hostfile").Parse(lp.hostfileFormat); err != nil {
+		return fmt.Errorf("invalid hostfile format %q: %v", lp.hostfileFormat, err)
+	}
+	if _, err := template.New("hosts").Parse(lp.hostsFormat); err != nil {
+		return fmt.Errorf("invalid hosts format %q: %v", lp.hostsFormat, err)
+	}
+	return nil
+}
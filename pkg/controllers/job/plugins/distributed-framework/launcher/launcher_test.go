@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package launcher
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func TestLauncher(t *testing.T) {
+	job := func(replicas int32, resources v1.ResourceRequirements) *v1alpha1.Job {
+		return &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-launcher", Namespace: "test"},
+			Spec: v1alpha1.JobSpec{
+				Tasks: []v1alpha1.TaskSpec{
+					{
+						Name:     "launcher",
+						Replicas: 1,
+						Template: v1.PodTemplateSpec{},
+					},
+					{
+						Name:     "worker",
+						Replicas: replicas,
+						Template: v1.PodTemplateSpec{
+							Spec: v1.PodSpec{
+								Containers: []v1.Container{{Name: "worker", Resources: resources}},
+							},
+						},
+					},
+				},
+			},
+			Status: v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+		}
+	}
+	requests := func(cpu, gpu string) v1.ResourceRequirements {
+		resources := v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}}
+		if gpu != "" {
+			resources.Limits = v1.ResourceList{GPUResourceName: resource.MustParse(gpu)}
+		}
+		return resources
+	}
+
+	testcases := []struct {
+		Name      string
+		Arguments []string
+		Job       *v1alpha1.Job
+		Data      map[string]string
+		Envs      []string
+		MountPath string
+	}{
+		{
+			Name:      "deepspeed with gpus",
+			Arguments: []string{"--preset=deepspeed"},
+			Job:       job(2, requests("4", "2")),
+			Data: map[string]string{
+				"hostfile":  "test-launcher-worker-0.test-launcher slots=2\ntest-launcher-worker-1.test-launcher slots=2\n",
+				"hosts":     "test-launcher-worker-0.test-launcher,test-launcher-worker-1.test-launcher",
+				"num_hosts": "2",
+				"num_slots": "4",
+			},
+			Envs:      []string{EnvHostfile, EnvHosts, EnvNumHosts, EnvNumSlots},
+			MountPath: "/job",
+		},
+		{
+			Name:      "horovod with cpus",
+			Arguments: []string{"--preset=horovod"},
+			Job:       job(2, requests("2500m", "")),
+			Data: map[string]string{
+				"hostfile":  "test-launcher-worker-0.test-launcher slots=2\ntest-launcher-worker-1.test-launcher slots=2\n",
+				"hosts":     "test-launcher-worker-0.test-launcher:2,test-launcher-worker-1.test-launcher:2",
+				"num_hosts": "2",
+				"num_slots": "4",
+			},
+			Envs:      []string{EnvHostfile, EnvHosts, EnvNumHosts, EnvNumSlots},
+			MountPath: DefaultMountPath,
+		},
+		{
+			Name:      "paddle with fixed slots",
+			Arguments: []string{"--preset=paddle", "--slots=8", "--hostfile-name=ips"},
+			Job:       job(1, requests("500m", "")),
+			Data: map[string]string{
+				"ips":       "test-launcher-worker-0.test-launcher\n",
+				"hosts":     "test-launcher-worker-0.test-launcher",
+				"num_hosts": "1",
+				"num_slots": "8",
+			},
+			Envs:      []string{EnvHostfile, EnvHosts, "PADDLE_TRAINERS", EnvNumHosts, "PADDLE_TRAINERS_NUM", EnvNumSlots},
+			MountPath: DefaultMountPath,
+		},
+		{
+			Name:      "custom format",
+			Arguments: []string{"--hostfile-format={{.Index}}={{.Host}}", "--mount-path=/etc/mpi"},
+			Job:       job(1, requests("1", "")),
+			Data: map[string]string{
+				"hostfile":  "0=test-launcher-worker-0.test-launcher\n",
+				"hosts":     "test-launcher-worker-0.test-launcher",
+				"num_hosts": "1",
+				"num_slots": "1",
+			},
+			Envs:      []string{EnvHostfile, EnvHosts, EnvNumHosts, EnvNumSlots},
+			MountPath: "/etc/mpi",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			client := pluginsinterface.PluginClientset{KubeClients: fake.NewSimpleClientset()}
+			lp := New(client, testcase.Arguments)
+
+			if err := lp.OnJobAdd(testcase.Job); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cm, err := client.KubeClients.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-launcher-launcher", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected the hostfile to be created, got error: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(cm.Data, testcase.Data) {
+				t.Errorf("expected hostfile %v, got %v", testcase.Data, cm.Data)
+			}
+
+			for _, task := range []string{"launcher", "worker"} {
+				pod := &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test-launcher-" + task + "-0",
+						Annotations: map[string]string{v1alpha1.TaskSpecKey: task},
+					},
+					Spec: v1.PodSpec{Containers: []v1.Container{{Name: task}}},
+				}
+				if err := lp.OnPodCreate(pod, testcase.Job); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				var envs []string
+				for _, env := range pod.Spec.Containers[0].Env {
+					envs = append(envs, env.Name)
+				}
+				if !equality.Semantic.DeepEqual(envs, testcase.Envs) {
+					t.Errorf("expected envs %v of %s, got %v", testcase.Envs, task, envs)
+				}
+
+				mounts := pod.Spec.Containers[0].VolumeMounts
+				if task == "worker" {
+					if len(mounts) != 0 {
+						t.Errorf("expected no hostfile mounted in worker, got %v", mounts)
+					}
+					continue
+				}
+				if len(mounts) != 1 || mounts[0].MountPath != testcase.MountPath {
+					t.Errorf("expected hostfile mounted in %s of launcher, got %v", testcase.MountPath, mounts)
+				}
+			}
+
+			if err := lp.OnJobDelete(testcase.Job); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := client.KubeClients.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-launcher-launcher", metav1.GetOptions{}); err == nil {
+				t.Errorf("expected the hostfile to be deleted")
+			}
+		})
+	}
+}
+
+func TestLauncherScale(t *testing.T) {
+	client := pluginsinterface.PluginClientset{KubeClients: fake.NewSimpleClientset()}
+	lp := New(client, []string{"--preset=deepspeed", "--slots=1"})
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-launcher", Namespace: "test"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{{Name: "worker", Replicas: 1}},
+		},
+		Status: v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+	}
+	if err := lp.OnJobAdd(job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	job.Spec.Tasks[0].Replicas = 3
+	if err := lp.OnJobUpdate(job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm, err := client.KubeClients.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-launcher-launcher", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cm.Data["num_hosts"] != "3" || cm.Data["num_slots"] != "3" {
+		t.Errorf("expected hostfile of 3 hosts after scaling up, got %v", cm.Data)
+	}
+}
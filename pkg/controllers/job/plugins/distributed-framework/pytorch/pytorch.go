@@ -31,6 +31,17 @@ const (
 	EnvWorldSize = "WORLD_SIZE"
 	// EnvRank is the env name of rank
 	EnvRank = "RANK"
+
+	// DefaultRdzvBackend is the default rendezvous backend in elastic mode
+	DefaultRdzvBackend = "c10d"
+	// EnvRdzvBackend is the env name of the rendezvous backend of torchrun
+	EnvRdzvBackend = "PET_RDZV_BACKEND"
+	// EnvRdzvEndpoint is the env name of the rendezvous endpoint of torchrun
+	EnvRdzvEndpoint = "PET_RDZV_ENDPOINT"
+	// EnvRdzvID is the env name of the rendezvous id of torchrun
+	EnvRdzvID = "PET_RDZV_ID"
+	// EnvNNodes is the env name of the range of the number of nodes of torchrun
+	EnvNNodes = "PET_NNODES"
 )
 
 type pytorchPlugin struct {
@@ -39,6 +50,12 @@ type pytorchPlugin struct {
 	masterName       string
 	workerName       string
 	port             int
+
+	// elastic sets the rendezvous of torchrun instead of static ranks, so
+	// that the job is scaled between minAvailable and maxNodes.
+	elastic     bool
+	rdzvBackend string
+	maxNodes    int
 }
 
 // New creates pytorch plugin.
@@ -53,6 +70,9 @@ func (pp *pytorchPlugin) addFlags() {
 	flagSet.StringVar(&pp.masterName, "master", DefaultMaster, "name of master role task")
 	flagSet.StringVar(&pp.workerName, "worker", DefaultWorker, "name of worker role task")
 	flagSet.IntVar(&pp.port, "port", DefaultPort, "open port for containers")
+	flagSet.BoolVar(&pp.elastic, "elastic", false, "run torchrun in elastic mode with a rendezvous on the master")
+	flagSet.StringVar(&pp.rdzvBackend, "rdzv-backend", DefaultRdzvBackend, "rendezvous backend in elastic mode")
+	flagSet.IntVar(&pp.maxNodes, "max-nodes", 0, "maximum number of nodes in elastic mode, required in elastic mode")
 	if err := flagSet.Parse(pp.pytorchArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", pp.Name(), err)
 	}
@@ -62,7 +82,29 @@ func (pp *pytorchPlugin) Name() string {
 	return PytorchPluginName
 }
 
+// Elastic returns whether the pytorch plugin of the job runs in elastic mode.
+func Elastic(job *batch.Job) bool {
+	_, elastic := ElasticMaxNodes(job)
+	return elastic
+}
+
+// ElasticMaxNodes returns the maximum number of nodes of the pytorch plugin of the job,
+// and whether it runs in elastic mode.
+func ElasticMaxNodes(job *batch.Job) (int32, bool) {
+	arguments, found := job.Spec.Plugins[PytorchPluginName]
+	if !found {
+		return 0, false
+	}
+	pp := pytorchPlugin{pytorchArguments: arguments}
+	pp.addFlags()
+	return int32(pp.maxNodes), pp.elastic
+}
+
 func (pp *pytorchPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	if pp.elastic {
+		return pp.onElasticPodCreate(pod, job)
+	}
+
 	taskType := helpers.GetTaskKey(pod)
 	masterIndex := helpers.GetTaskIndexUnderJob(pp.masterName, job)
 	if masterIndex == -1 {
@@ -117,6 +159,63 @@ func (pp *pytorchPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 	return nil
 }
 
+// onElasticPodCreate sets the rendezvous of torchrun, the ranks and the world size are
+// assigned by the rendezvous so that the pods join and leave as the job is scaled.
+func (pp *pytorchPlugin) onElasticPodCreate(pod *v1.Pod, job *batch.Job) error {
+	rdzvIndex := helpers.GetTaskIndexUnderJob(pp.masterName, job)
+	if rdzvIndex == -1 {
+		rdzvIndex = helpers.GetTaskIndexUnderJob(pp.workerName, job)
+	}
+	if rdzvIndex == -1 {
+		klog.Errorf("job %v doesn't have task %v or %v", job.Name, pp.masterName, pp.workerName)
+		return nil
+	}
+	if pp.maxNodes <= 0 {
+		return fmt.Errorf("job %v doesn't set --max-nodes of the pytorch plugin in elastic mode", job.Name)
+	}
+
+	minNodes, maxNodes := pp.getNodeRange(job)
+	envVars := []v1.EnvVar{
+		{
+			Name:  EnvRdzvBackend,
+			Value: pp.rdzvBackend,
+		},
+		{
+			Name:  EnvRdzvEndpoint,
+			Value: fmt.Sprintf("%s:%v", pp.generateMasterAddr(job.Spec.Tasks[rdzvIndex], job.Name), pp.port),
+		},
+		{
+			Name:  EnvRdzvID,
+			Value: job.Name,
+		},
+		{
+			Name:  EnvNNodes,
+			Value: fmt.Sprintf("%d:%d", minNodes, maxNodes),
+		},
+	}
+
+	for i, c := range pod.Spec.Containers {
+		pp.openContainerPort(&c, i, pod)
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
+	}
+
+	return nil
+}
+
+// getNodeRange returns the minimum and maximum number of nodes of torchrun in elastic
+// mode, the job runs with its minAvailable pods and is scaled up to maxNodes.
+func (pp *pytorchPlugin) getNodeRange(job *batch.Job) (int32, int32) {
+	replicas := pp.getTotalReplicas(job)
+	minNodes, maxNodes := job.Spec.MinAvailable, int32(pp.maxNodes)
+	if minNodes <= 0 || minNodes > replicas {
+		minNodes = replicas
+	}
+	if minNodes > maxNodes {
+		minNodes = maxNodes
+	}
+	return minNodes, maxNodes
+}
+
 func (pp *pytorchPlugin) getTotalReplicas(job *batch.Job) int32 {
 	jobReplicas := int32(0)
 	for _, task := range job.Spec.Tasks {
@@ -175,6 +274,10 @@ func (pp *pytorchPlugin) OnJobDelete(job *batch.Job) error {
 	return nil
 }
 
+// OnJobUpdate is called when the job is scaled up or down. In elastic mode, the
+// pods created on scale up join the rendezvous and the pods deleted on scale down
+// leave it, the other pods are kept running. The admission webhook rejects scaling
+// the job beyond maxNodes.
 func (pp *pytorchPlugin) OnJobUpdate(job *batch.Job) error {
 	return nil
 }
@@ -371,3 +371,87 @@ func TestPytorch(t *testing.T) {
 		})
 	}
 }
+
+func TestPytorchElastic(t *testing.T) {
+	job := func(minAvailable int32, arguments []string, tasks ...v1alpha1.TaskSpec) *v1alpha1.Job {
+		return &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pytorch"},
+			Spec: v1alpha1.JobSpec{
+				MinAvailable: minAvailable,
+				Plugins:      map[string][]string{PytorchPluginName: arguments},
+				Tasks:        tasks,
+			},
+		}
+	}
+	task := func(name string, replicas int32) v1alpha1.TaskSpec {
+		return v1alpha1.TaskSpec{Name: name, Replicas: replicas, Template: v1.PodTemplateSpec{}}
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-pytorch-worker-0",
+			Annotations: map[string]string{
+				v1alpha1.TaskSpecKey: "worker",
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "worker",
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		Name        string
+		Job         *v1alpha1.Job
+		envs        []v1.EnvVar
+		expectedErr bool
+	}{
+		{
+			Name: "test elastic job with master",
+			Job:  job(2, []string{"--elastic", "--max-nodes=4"}, task("master", 1), task("worker", 3)),
+			envs: []v1.EnvVar{
+				{Name: EnvRdzvBackend, Value: DefaultRdzvBackend},
+				{Name: EnvRdzvEndpoint, Value: fmt.Sprintf("test-pytorch-master-0.test-pytorch:%v", DefaultPort)},
+				{Name: EnvRdzvID, Value: "test-pytorch"},
+				{Name: EnvNNodes, Value: "2:4"},
+			},
+		},
+		{
+			Name: "test elastic job without master",
+			Job:  job(0, []string{"--elastic", "--rdzv-backend=etcd-v2", "--port=2379", "--max-nodes=8"}, task("worker", 4)),
+			envs: []v1.EnvVar{
+				{Name: EnvRdzvBackend, Value: "etcd-v2"},
+				{Name: EnvRdzvEndpoint, Value: "test-pytorch-worker-0.test-pytorch:2379"},
+				{Name: EnvRdzvID, Value: "test-pytorch"},
+				{Name: EnvNNodes, Value: "4:8"},
+			},
+		},
+		{
+			Name:        "test elastic job without max nodes",
+			Job:         job(1, []string{"--elastic"}, task("worker", 3)),
+			envs:        nil,
+			expectedErr: true,
+		},
+	}
+
+	for index, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if !Elastic(testcase.Job) {
+				t.Errorf("Case %d (%s): expected the job to be elastic", index, testcase.Name)
+			}
+			pp := New(pluginsinterface.PluginClientset{}, testcase.Job.Spec.Plugins[PytorchPluginName])
+			p := pod.DeepCopy()
+			if err := pp.OnPodCreate(p, testcase.Job); (err != nil) != testcase.expectedErr {
+				t.Fatalf("Case %d (%s): expected error %v, got %v", index, testcase.Name, testcase.expectedErr, err)
+			}
+			if testcase.expectedErr {
+				return
+			}
+			if !equality.Semantic.DeepEqual(p.Spec.Containers[0].Env, testcase.envs) {
+				t.Errorf("Case %d (%s): wrong envs, got %v, expected %v", index, testcase.Name, p.Spec.Containers[0].Env, testcase.envs)
+			}
+		})
+	}
+}
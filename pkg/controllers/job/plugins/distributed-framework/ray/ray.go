@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ray
+
+import (
+	"flag"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// RayPluginName is the name of the plugin
+	RayPluginName = "ray"
+	// DefaultHead is the default task name of head host
+	DefaultHead = "head"
+	// DefaultWorker is the default task name of worker host
+	DefaultWorker = "worker"
+	// DefaultPort is the default port of the GCS server on the head
+	DefaultPort = 6379
+	// DefaultDashboardPort is the default port of the dashboard on the head
+	DefaultDashboardPort = 8265
+	// DefaultClientPort is the default port of the Ray client server on the head
+	DefaultClientPort = 10001
+
+	// EnvRayIP is the env name of the address of the head
+	EnvRayIP = "RAY_IP"
+	// EnvRayPort is the env name of the port of the GCS server
+	EnvRayPort = "RAY_PORT"
+	// EnvRayAddress is the env name of the address of the GCS server, the workers join the cluster with it
+	EnvRayAddress = "RAY_ADDRESS"
+	// EnvDashboardPort is the env name of the port of the dashboard
+	EnvDashboardPort = "RAY_DASHBOARD_PORT"
+	// EnvClientPort is the env name of the port of the Ray client server
+	EnvClientPort = "RAY_CLIENT_PORT"
+)
+
+// Plugin wires the head task and the worker tasks of a Ray cluster, the
+// head is reached through the headless service of the svc plugin.
+type Plugin struct {
+	rayArguments  []string
+	clientset     pluginsinterface.PluginClientset
+	headName      string
+	workerName    string
+	port          int
+	dashboardPort int
+	clientPort    int
+}
+
+// New creates ray plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	rp := Plugin{rayArguments: arguments, clientset: client}
+	rp.addFlags()
+	return &rp
+}
+
+// NewInstance creates ray plugin without clientset, e.g. to validate jobs.
+func NewInstance(arguments []string) Plugin {
+	rp := Plugin{rayArguments: arguments}
+	rp.addFlags()
+	return rp
+}
+
+func (rp *Plugin) addFlags() {
+	flagSet := flag.NewFlagSet(rp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&rp.headName, "head", DefaultHead, "name of head role task")
+	flagSet.StringVar(&rp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&rp.port, "port", DefaultPort, "port of the GCS server on the head")
+	flagSet.IntVar(&rp.dashboardPort, "dashboard-port", DefaultDashboardPort, "port of the dashboard on the head")
+	flagSet.IntVar(&rp.clientPort, "client-port", DefaultClientPort, "port of the Ray client server on the head")
+	if err := flagSet.Parse(rp.rayArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", rp.Name(), err)
+	}
+}
+
+func (rp *Plugin) Name() string {
+	return RayPluginName
+}
+
+func (rp *Plugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	headIndex := helpers.GetTaskIndexUnderJob(rp.headName, job)
+	if headIndex == -1 {
+		klog.Errorf("job %v doesn't have task %v", job.Name, rp.headName)
+		return nil
+	}
+
+	headAddr := rp.generateHeadAddr(job.Spec.Tasks[headIndex], job.Name)
+	envVars := []v1.EnvVar{
+		{
+			Name:  EnvRayIP,
+			Value: headAddr,
+		},
+		{
+			Name:  EnvRayPort,
+			Value: fmt.Sprintf("%v", rp.port),
+		},
+		{
+			Name:  EnvRayAddress,
+			Value: fmt.Sprintf("%s:%v", headAddr, rp.port),
+		},
+		{
+			Name:  EnvDashboardPort,
+			Value: fmt.Sprintf("%v", rp.dashboardPort),
+		},
+		{
+			Name:  EnvClientPort,
+			Value: fmt.Sprintf("%v", rp.clientPort),
+		},
+	}
+
+	isHead := helpers.GetTaskKey(pod) == rp.headName
+	for i := range pod.Spec.Containers {
+		if isHead {
+			rp.openContainerPort(&pod.Spec.Containers[i], "gcs-server", rp.port)
+			rp.openContainerPort(&pod.Spec.Containers[i], "dashboard", rp.dashboardPort)
+			rp.openContainerPort(&pod.Spec.Containers[i], "client", rp.clientPort)
+		}
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
+	}
+
+	return nil
+}
+
+func (rp *Plugin) generateHeadAddr(task batch.TaskSpec, jobName string) string {
+	hostName := task.Template.Spec.Hostname
+	subdomain := task.Template.Spec.Subdomain
+	if len(hostName) == 0 {
+		hostName = helpers.MakePodName(jobName, task.Name, 0)
+	}
+	if len(subdomain) == 0 {
+		subdomain = jobName
+	}
+
+	return hostName + "." + subdomain
+}
+
+func (rp *Plugin) openContainerPort(c *v1.Container, name string, port int) {
+	for _, p := range c.Ports {
+		if p.ContainerPort == int32(port) {
+			return
+		}
+	}
+
+	c.Ports = append(c.Ports, v1.ContainerPort{
+		Name:          name,
+		ContainerPort: int32(port),
+	})
+}
+
+func (rp *Plugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+rp.Name()] == rp.Name() {
+		return nil
+	}
+	job.Status.ControlledResources["plugin-"+rp.Name()] = rp.Name()
+	return nil
+}
+
+func (rp *Plugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+rp.Name()] != rp.Name() {
+		return nil
+	}
+	delete(job.Status.ControlledResources, "plugin-"+rp.Name())
+	return nil
+}
+
+func (rp *Plugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
+
+func (rp *Plugin) GetHeadName() string {
+	return rp.headName
+}
+
+func (rp *Plugin) GetWorkerName() string {
+	return rp.workerName
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ray
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func TestRay(t *testing.T) {
+	job := func(plugins map[string][]string) *v1alpha1.Job {
+		return &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ray"},
+			Spec: v1alpha1.JobSpec{
+				Plugins: plugins,
+				Tasks: []v1alpha1.TaskSpec{
+					{
+						Name:     "head",
+						Replicas: 1,
+						Template: v1.PodTemplateSpec{},
+					},
+					{
+						Name:     "worker",
+						Replicas: 2,
+						Template: v1.PodTemplateSpec{},
+					},
+				},
+			},
+		}
+	}
+	pod := func(task string, ports ...v1.ContainerPort) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ray-" + task + "-0",
+				Annotations: map[string]string{
+					v1alpha1.TaskSpecKey: task,
+				},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:  task,
+						Ports: ports,
+					},
+				},
+			},
+		}
+	}
+	envs := func(port, dashboardPort, clientPort int) []v1.EnvVar {
+		return []v1.EnvVar{
+			{Name: EnvRayIP, Value: "test-ray-head-0.test-ray"},
+			{Name: EnvRayPort, Value: fmt.Sprintf("%v", port)},
+			{Name: EnvRayAddress, Value: fmt.Sprintf("test-ray-head-0.test-ray:%v", port)},
+			{Name: EnvDashboardPort, Value: fmt.Sprintf("%v", dashboardPort)},
+			{Name: EnvClientPort, Value: fmt.Sprintf("%v", clientPort)},
+		}
+	}
+
+	testcases := []struct {
+		Name  string
+		Job   *v1alpha1.Job
+		Pod   *v1.Pod
+		ports []int32
+		envs  []v1.EnvVar
+	}{
+		{
+			Name: "test pod without head",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ray"},
+				Spec: v1alpha1.JobSpec{
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{},
+						},
+					},
+				},
+			},
+			Pod:   pod("worker"),
+			ports: nil,
+			envs:  nil,
+		},
+		{
+			Name:  "test head pod",
+			Job:   job(nil),
+			Pod:   pod("head"),
+			ports: []int32{DefaultPort, DefaultDashboardPort, DefaultClientPort},
+			envs:  envs(DefaultPort, DefaultDashboardPort, DefaultClientPort),
+		},
+		{
+			Name:  "test head pod with port",
+			Job:   job(nil),
+			Pod:   pod("head", v1.ContainerPort{Name: "redis", ContainerPort: DefaultPort}),
+			ports: []int32{DefaultPort, DefaultDashboardPort, DefaultClientPort},
+			envs:  envs(DefaultPort, DefaultDashboardPort, DefaultClientPort),
+		},
+		{
+			Name:  "test worker pod",
+			Job:   job(nil),
+			Pod:   pod("worker"),
+			ports: nil,
+			envs:  envs(DefaultPort, DefaultDashboardPort, DefaultClientPort),
+		},
+		{
+			Name:  "test head pod with arguments",
+			Job:   job(map[string][]string{RayPluginName: {"--port=6380", "--dashboard-port=8266", "--client-port=10002"}}),
+			Pod:   pod("head"),
+			ports: []int32{6380, 8266, 10002},
+			envs:  envs(6380, 8266, 10002),
+		},
+	}
+
+	for index, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			rp := New(pluginsinterface.PluginClientset{}, testcase.Job.Spec.Plugins[RayPluginName])
+			if err := rp.OnPodCreate(testcase.Pod, testcase.Job); err != nil {
+				t.Errorf("Case %d (%s): expect no error, but got error %v", index, testcase.Name, err)
+			}
+
+			var ports []int32
+			for _, port := range testcase.Pod.Spec.Containers[0].Ports {
+				ports = append(ports, port.ContainerPort)
+			}
+			if !equality.Semantic.DeepEqual(ports, testcase.ports) {
+				t.Errorf("Case %d (%s): wrong ports, got %v, expected %v", index, testcase.Name, ports, testcase.ports)
+			}
+
+			if !equality.Semantic.DeepEqual(testcase.Pod.Spec.Containers[0].Env, testcase.envs) {
+				t.Errorf("Case %d (%s): wrong envs, got %v, expected %v", index, testcase.Name, testcase.Pod.Spec.Containers[0].Env, testcase.envs)
+			}
+		})
+	}
+}
@@ -17,10 +17,18 @@ limitations under the License.
 package plugins
 
 import (
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"strings"
 	"sync"
 
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/launcher"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/env"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
@@ -35,6 +43,8 @@ func init() {
 	RegisterPluginBuilder("tensorflow", tensorflow.New)
 	RegisterPluginBuilder("mpi", mpi.New)
 	RegisterPluginBuilder("pytorch", pytorch.New)
+	RegisterPluginBuilder("ray", ray.New)
+	RegisterPluginBuilder("launcher", launcher.New)
 }
 
 var pluginMutex sync.Mutex
@@ -61,3 +71,39 @@ func GetPluginBuilder(name string) (PluginBuilder, bool) {
 	pb, found := pluginBuilders[name]
 	return pb, found
 }
+
+// LoadCustomPlugins loads custom implement plugins, the plugins are named after their file
+// and built by their exported `New` function of the PluginBuilder prototype.
+func LoadCustomPlugins(pluginsDir string) error {
+	pluginPaths, _ := filepath.Glob(fmt.Sprintf("%s/*.so", pluginsDir))
+	for _, pluginPath := range pluginPaths {
+		pluginBuilder, err := loadPluginBuilder(pluginPath)
+		if err != nil {
+			return err
+		}
+		pluginName := strings.TrimSuffix(filepath.Base(pluginPath), filepath.Ext(pluginPath))
+		RegisterPluginBuilder(pluginName, pluginBuilder)
+		klog.V(4).Infof("Custom job plugin %s loaded", pluginName)
+	}
+
+	return nil
+}
+
+func loadPluginBuilder(pluginPath string) (PluginBuilder, error) {
+	plug, err := plugin.Open(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+
+	symBuilder, err := plug.Lookup("New")
+	if err != nil {
+		return nil, err
+	}
+
+	builder, ok := symBuilder.(func(pluginsinterface.PluginClientset, []string) pluginsinterface.PluginInterface)
+	if !ok {
+		return nil, fmt.Errorf("unexpected plugin: %s, failed to convert PluginBuilder `New`", pluginPath)
+	}
+
+	return builder, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugintest provides utilities to test job plugins, in or out of tree,
+// the way the plugins of Volcano are tested.
+package plugintest
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+// BuildJob builds a job of the tasks with the plugins, as the job controller hands it to them.
+func BuildJob(name, namespace string, jobPlugins map[string][]string, tasks ...batch.TaskSpec) *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: batch.JobSpec{
+			Plugins: jobPlugins,
+			Tasks:   tasks,
+		},
+		Status: batch.JobStatus{
+			ControlledResources: map[string]string{},
+		},
+	}
+}
+
+// BuildTask builds a task of the containers.
+func BuildTask(name string, replicas int32, containers ...v1.Container) batch.TaskSpec {
+	return batch.TaskSpec{
+		Name:     name,
+		Replicas: replicas,
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: containers,
+			},
+		},
+	}
+}
+
+// BuildPod builds the pod of the index of the task, as the job controller creates it before calling the plugins.
+func BuildPod(job *batch.Job, task string, index int) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobhelpers.MakePodName(job.Name, task, index),
+			Namespace: job.Namespace,
+			Annotations: map[string]string{
+				batch.TaskSpecKey: task,
+				batch.JobNameKey:  job.Name,
+			},
+		},
+	}
+	if i := jobhelpers.GetTaskIndexUnderJob(task, job); i != -1 {
+		pod.Spec = *job.Spec.Tasks[i].Template.Spec.DeepCopy()
+	}
+	return pod
+}
+
+// PodCase is a test case of OnPodCreate.
+type PodCase struct {
+	Name      string
+	Arguments []string
+	Job       *batch.Job
+	Pod       *v1.Pod
+	// ExpectedPod is the pod after OnPodCreate, it is not checked if nil.
+	ExpectedPod *v1.Pod
+	ExpectErr   bool
+}
+
+// RunPodCases runs the test cases of OnPodCreate against the plugins built by the builder.
+func RunPodCases(t *testing.T, builder plugins.PluginBuilder, cases []PodCase) {
+	t.Helper()
+	for _, testcase := range cases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			plugin := builder(pluginsinterface.PluginClientset{KubeClients: fake.NewSimpleClientset()}, testcase.Arguments)
+			pod := testcase.Pod.DeepCopy()
+			err := plugin.OnPodCreate(pod, testcase.Job)
+			if testcase.ExpectErr {
+				if err == nil {
+					t.Errorf("expected error of plugin %s on pod %s, got nil", plugin.Name(), pod.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error of plugin %s on pod %s: %v", plugin.Name(), pod.Name, err)
+			}
+			if testcase.ExpectedPod != nil && !equality.Semantic.DeepEqual(pod, testcase.ExpectedPod) {
+				t.Errorf("expected pod %+v, got %+v", testcase.ExpectedPod, pod)
+			}
+		})
+	}
+}
+
+// Lifecycle is the check of the resources of the plugin at each step of the lifecycle of the job.
+type Lifecycle struct {
+	// Added is called after the job is added.
+	Added func(t *testing.T, job *batch.Job, client kubernetes.Interface)
+	// Updated is called after the job is updated.
+	Updated func(t *testing.T, job *batch.Job, client kubernetes.Interface)
+	// Deleted is called after the job is deleted.
+	Deleted func(t *testing.T, job *batch.Job, client kubernetes.Interface)
+}
+
+// RunLifecycle adds, updates and deletes the job with the plugin built by the builder, and
+// checks the resources of the plugin at each step. OnJobAdd and OnJobDelete are called twice as
+// they must be idempotent, and the plugin must not be left in the controlled resources of the job.
+func RunLifecycle(t *testing.T, builder plugins.PluginBuilder, arguments []string, job *batch.Job, lifecycle Lifecycle) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	plugin := builder(pluginsinterface.PluginClientset{KubeClients: client}, arguments)
+
+	for i := 0; i < 2; i++ {
+		if err := plugin.OnJobAdd(job); err != nil {
+			t.Fatalf("unexpected error of plugin %s on job add: %v", plugin.Name(), err)
+		}
+	}
+	if lifecycle.Added != nil {
+		lifecycle.Added(t, job, client)
+	}
+
+	if err := plugin.OnJobUpdate(job); err != nil {
+		t.Fatalf("unexpected error of plugin %s on job update: %v", plugin.Name(), err)
+	}
+	if lifecycle.Updated != nil {
+		lifecycle.Updated(t, job, client)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := plugin.OnJobDelete(job); err != nil {
+			t.Fatalf("unexpected error of plugin %s on job delete: %v", plugin.Name(), err)
+		}
+	}
+	if _, found := job.Status.ControlledResources["plugin-"+plugin.Name()]; found {
+		t.Errorf("expected plugin %s to be removed from the controlled resources of the job after delete", plugin.Name())
+	}
+	if lifecycle.Deleted != nil {
+		lifecycle.Deleted(t, job, client)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+// NewHandler serves the plugins built by the builder to the job controller, so that a plugin
+// implemented against PluginInterface runs out of the controller the same way it runs in it.
+func NewHandler(builder plugins.PluginBuilder, clientset pluginsinterface.PluginClientset) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &Request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.Job == nil {
+			http.Error(w, "no job in request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := serve(builder(clientset, req.Arguments), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			klog.Errorf("Failed to encode the response of plugin %s at <%s>: %v", req.Plugin, req.Hook, err)
+		}
+	})
+}
+
+// serve calls the hook of the plugin, the errors of the plugin are returned in the response.
+func serve(plugin pluginsinterface.PluginInterface, req *Request) (*Response, error) {
+	job := req.Job
+	if job.Status.ControlledResources == nil {
+		job.Status.ControlledResources = make(map[string]string)
+	}
+	original := make(map[string]string, len(job.Status.ControlledResources))
+	for key, value := range job.Status.ControlledResources {
+		original[key] = value
+	}
+
+	resp := &Response{}
+	var err error
+	switch req.Hook {
+	case HookOnPodCreate:
+		if req.Pod == nil {
+			return nil, fmt.Errorf("no pod in request of hook %s", req.Hook)
+		}
+		resp.Patch, err = podCreatePatch(plugin, req.Pod, job)
+	case HookOnJobAdd:
+		err = plugin.OnJobAdd(job)
+	case HookOnJobDelete:
+		err = plugin.OnJobDelete(job)
+	case HookOnJobUpdate:
+		err = plugin.OnJobUpdate(job)
+	default:
+		return nil, fmt.Errorf("unknown hook %s", req.Hook)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.ControlledResources = controlledResourcesChanges(original, job.Status.ControlledResources)
+	return resp, nil
+}
+
+// controlledResourcesChanges returns the controlled resources changed by the hook, the deleted ones are null.
+func controlledResourcesChanges(original, current map[string]string) map[string]*string {
+	changes := map[string]*string{}
+	for key, value := range current {
+		if originalValue, found := original[key]; !found || originalValue != value {
+			value := value
+			changes[key] = &value
+		}
+	}
+	for key := range original {
+		if _, found := current[key]; !found {
+			changes[key] = nil
+		}
+	}
+	return changes
+}
+
+// podCreatePatch calls OnPodCreate of the plugin and returns the patch of the pod.
+func podCreatePatch(plugin pluginsinterface.PluginInterface, pod *v1.Pod, job *batch.Job) (json.RawMessage, error) {
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+	if err := plugin.OnPodCreate(pod, job); err != nil {
+		return nil, err
+	}
+	modified, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.CreateTwoWayMergePatch(original, modified, v1.Pod{})
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+// The hooks of the plugins called through the webhooks.
+const (
+	HookOnPodCreate = "OnPodCreate"
+	HookOnJobAdd    = "OnJobAdd"
+	HookOnJobDelete = "OnJobDelete"
+	HookOnJobUpdate = "OnJobUpdate"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Request is sent to the webhook of a plugin for every hook called on the plugin.
+type Request struct {
+	// Hook is the hook called, e.g. OnPodCreate.
+	Hook string `json:"hook"`
+	// Plugin is the name of the plugin.
+	Plugin string `json:"plugin"`
+	// Arguments are the arguments of the plugin in the job.
+	Arguments []string `json:"arguments,omitempty"`
+	// Job is the job the hook is called on.
+	Job *batch.Job `json:"job"`
+	// Pod is the pod being created, it is only set for OnPodCreate.
+	Pod *v1.Pod `json:"pod,omitempty"`
+}
+
+// Response is returned by the webhook of a plugin.
+type Response struct {
+	// Patch is the strategic merge patch of the pod, it is only returned for OnPodCreate.
+	Patch json.RawMessage `json:"patch,omitempty"`
+	// ControlledResources are merged into the controlled resources in the status of the job,
+	// the plugin records the resources it created there. The keys with a null value are deleted,
+	// the keys which are not returned are left as they are.
+	ControlledResources map[string]*string `json:"controlledResources,omitempty"`
+	// Error is the error of the hook, the hook failed if it is not empty.
+	Error string `json:"error,omitempty"`
+}
+
+// Configuration is the configuration of the plugins served by webhooks.
+type Configuration struct {
+	Plugins []Config `json:"plugins"`
+}
+
+// Config is the configuration of a plugin served by a webhook.
+type Config struct {
+	// Name is the name of the plugin in the jobs.
+	Name string `json:"name"`
+	// URL is the url the requests are posted to.
+	URL string `json:"url"`
+	// CAFile is the file of the CA certificates the certificate of the webhook is verified with,
+	// the CA certificates of the host are used if it is empty.
+	CAFile string `json:"caFile,omitempty"`
+	// Timeout is the timeout of a call to the webhook, 10s by default.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Ignorable indicates whether the failures to call the webhook are ignored,
+	// the errors returned by the plugin are not.
+	Ignorable bool `json:"ignorable,omitempty"`
+}
+
+// LoadConfiguration reads the configuration of the plugins served by webhooks from the file.
+func LoadConfiguration(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin webhook config file %s: %v", path, err)
+	}
+	conf := &Configuration{}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin webhook config file %s: %v", path, err)
+	}
+	return conf, nil
+}
+
+// RegisterPlugins registers the plugins served by webhooks, along the plugins of Volcano.
+func RegisterPlugins(conf *Configuration) error {
+	for i := range conf.Plugins {
+		config := conf.Plugins[i]
+		if len(config.Name) == 0 || len(config.URL) == 0 {
+			return fmt.Errorf("plugin webhook %d must have a name and an url", i)
+		}
+		if _, found := plugins.GetPluginBuilder(config.Name); found {
+			return fmt.Errorf("plugin %s is already registered", config.Name)
+		}
+		client, err := newClient(config)
+		if err != nil {
+			return err
+		}
+		plugins.RegisterPluginBuilder(config.Name, func(_ pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+			return &webhookPlugin{config: config, client: client, arguments: arguments}
+		})
+		klog.V(3).Infof("Plugin %s served by webhook %s registered", config.Name, config.URL)
+	}
+	return nil
+}
+
+func newClient(config Config) (*http.Client, error) {
+	timeout := config.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	if len(config.CAFile) == 0 {
+		return client, nil
+	}
+
+	caCert, err := os.ReadFile(config.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file %s of plugin webhook %s: %v", config.CAFile, config.Name, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA file %s of plugin webhook %s", config.CAFile, config.Name)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	client.Transport = transport
+	return client, nil
+}
+
+// webhookPlugin calls the webhook of the plugin for every hook.
+type webhookPlugin struct {
+	config    Config
+	client    *http.Client
+	arguments []string
+}
+
+func (wp *webhookPlugin) Name() string {
+	return wp.config.Name
+}
+
+func (wp *webhookPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	resp, err := wp.call(HookOnPodCreate, job, pod)
+	if err != nil || len(resp.Patch) == 0 {
+		return err
+	}
+
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, resp.Patch, v1.Pod{})
+	if err != nil {
+		return fmt.Errorf("failed to apply the patch of plugin %s to pod %s: %v", wp.Name(), pod.Name, err)
+	}
+	newPod := v1.Pod{}
+	if err := json.Unmarshal(patched, &newPod); err != nil {
+		return fmt.Errorf("failed to apply the patch of plugin %s to pod %s: %v", wp.Name(), pod.Name, err)
+	}
+	*pod = newPod
+	return nil
+}
+
+func (wp *webhookPlugin) OnJobAdd(job *batch.Job) error {
+	_, err := wp.call(HookOnJobAdd, job, nil)
+	return err
+}
+
+func (wp *webhookPlugin) OnJobDelete(job *batch.Job) error {
+	_, err := wp.call(HookOnJobDelete, job, nil)
+	return err
+}
+
+func (wp *webhookPlugin) OnJobUpdate(job *batch.Job) error {
+	_, err := wp.call(HookOnJobUpdate, job, nil)
+	return err
+}
+
+// call calls the webhook and records the controlled resources it returns in the job.
+// The failures to call the webhook are ignored if it is ignorable, an empty response is returned then.
+func (wp *webhookPlugin) call(hook string, job *batch.Job, pod *v1.Pod) (*Response, error) {
+	resp, err := wp.post(&Request{
+		Hook:      hook,
+		Plugin:    wp.Name(),
+		Arguments: wp.arguments,
+		Job:       job,
+		Pod:       pod,
+	})
+	if err != nil {
+		if wp.config.Ignorable {
+			klog.Warningf("Ignored the failure of plugin webhook %s at <%s> on job <%s/%s>: %v", wp.Name(), hook, job.Namespace, job.Name, err)
+			return &Response{}, nil
+		}
+		return nil, err
+	}
+	if len(resp.Error) != 0 {
+		return nil, fmt.Errorf("plugin %s failed at <%s>: %s", wp.Name(), hook, resp.Error)
+	}
+
+	if len(resp.ControlledResources) != 0 && job.Status.ControlledResources == nil {
+		job.Status.ControlledResources = make(map[string]string)
+	}
+	for key, value := range resp.ControlledResources {
+		if value == nil {
+			delete(job.Status.ControlledResources, key)
+			continue
+		}
+		job.Status.ControlledResources[key] = *value
+	}
+	return resp, nil
+}
+
+func (wp *webhookPlugin) post(req *Request) (*Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := wp.client.Post(wp.config.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call plugin webhook %s: %v", wp.Name(), err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return nil, fmt.Errorf("plugin webhook %s returned status %d: %s", wp.Name(), httpResp.StatusCode, bytes.TrimSpace(message))
+	}
+	resp := &Response{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of plugin webhook %s: %v", wp.Name(), err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/plugintest"
+)
+
+// samplePlugin sets the env SAMPLE to its argument, it fails on the pods of the task named fail.
+type samplePlugin struct {
+	arguments []string
+}
+
+func newSamplePlugin(_ pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	return &samplePlugin{arguments: arguments}
+}
+
+func (sp *samplePlugin) Name() string {
+	return "sample"
+}
+
+func (sp *samplePlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	if pod.Annotations[batch.TaskSpecKey] == "fail" {
+		return fmt.Errorf("task fail is not supported")
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: "SAMPLE", Value: fmt.Sprint(sp.arguments)})
+	}
+	return nil
+}
+
+func (sp *samplePlugin) OnJobAdd(job *batch.Job) error {
+	job.Status.ControlledResources["plugin-"+sp.Name()] = sp.Name()
+	return nil
+}
+
+func (sp *samplePlugin) OnJobDelete(job *batch.Job) error {
+	delete(job.Status.ControlledResources, "plugin-"+sp.Name())
+	return nil
+}
+
+func (sp *samplePlugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
+
+func registerWebhook(t *testing.T, config Config) plugins.PluginBuilder {
+	if err := RegisterPlugins(&Configuration{Plugins: []Config{config}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	builder, found := plugins.GetPluginBuilder(config.Name)
+	if !found {
+		t.Fatalf("expected plugin %s to be registered", config.Name)
+	}
+	return builder
+}
+
+func TestWebhookPlugin(t *testing.T) {
+	server := httptest.NewServer(NewHandler(newSamplePlugin, pluginsinterface.PluginClientset{}))
+	defer server.Close()
+	builder := registerWebhook(t, Config{Name: "sample", URL: server.URL})
+
+	job := plugintest.BuildJob("test-sample", "test", map[string][]string{"sample": {"--a"}},
+		plugintest.BuildTask("worker", 2, v1.Container{Name: "worker", Env: []v1.EnvVar{{Name: "EXISTING", Value: "1"}}}),
+		plugintest.BuildTask("fail", 1, v1.Container{Name: "fail"}))
+	expectedPod := plugintest.BuildPod(job, "worker", 1)
+	expectedPod.Spec.Containers[0].Env = append(expectedPod.Spec.Containers[0].Env, v1.EnvVar{Name: "SAMPLE", Value: "[--a]"})
+
+	plugintest.RunPodCases(t, builder, []plugintest.PodCase{
+		{
+			Name:        "pod patched by the webhook",
+			Arguments:   []string{"--a"},
+			Job:         job,
+			Pod:         plugintest.BuildPod(job, "worker", 1),
+			ExpectedPod: expectedPod,
+		},
+		{
+			Name:      "error of the plugin",
+			Job:       job,
+			Pod:       plugintest.BuildPod(job, "fail", 0),
+			ExpectErr: true,
+		},
+	})
+
+	job.Status.ControlledResources = map[string]string{"volume-pvc-data": "data"}
+	plugintest.RunLifecycle(t, builder, nil, job, plugintest.Lifecycle{
+		Added: func(t *testing.T, job *batch.Job, _ kubernetes.Interface) {
+			if job.Status.ControlledResources["plugin-sample"] != "sample" {
+				t.Errorf("expected the controlled resources of the webhook to be recorded, got %v", job.Status.ControlledResources)
+			}
+		},
+		Deleted: func(t *testing.T, job *batch.Job, _ kubernetes.Interface) {
+			if job.Status.ControlledResources["volume-pvc-data"] != "data" {
+				t.Errorf("expected the controlled resources of the other plugins to be kept, got %v", job.Status.ControlledResources)
+			}
+		},
+	})
+}
+
+func TestWebhookControlledResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"controlledResources": {"plugin-partial": "partial", "stale": null}}`)
+	}))
+	defer server.Close()
+	builder := registerWebhook(t, Config{Name: "partial", URL: server.URL})
+
+	job := plugintest.BuildJob("test-partial", "test", nil, plugintest.BuildTask("worker", 1, v1.Container{Name: "worker"}))
+	job.Status.ControlledResources = map[string]string{"volume-pvc-data": "data", "stale": "stale"}
+	if err := builder(pluginsinterface.PluginClientset{}, nil).OnJobAdd(job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"volume-pvc-data": "data", "plugin-partial": "partial"}
+	if !reflect.DeepEqual(job.Status.ControlledResources, expected) {
+		t.Errorf("expected controlled resources %v, got %v", expected, job.Status.ControlledResources)
+	}
+}
+
+func TestIgnorableWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	job := plugintest.BuildJob("test-sample", "test", nil, plugintest.BuildTask("worker", 1, v1.Container{Name: "worker"}))
+	pod := plugintest.BuildPod(job, "worker", 0)
+
+	plugintest.RunPodCases(t, registerWebhook(t, Config{Name: "unavailable", URL: server.URL}), []plugintest.PodCase{
+		{
+			Name:      "unavailable webhook",
+			Job:       job,
+			Pod:       pod,
+			ExpectErr: true,
+		},
+	})
+	plugintest.RunPodCases(t, registerWebhook(t, Config{Name: "ignorable", URL: server.URL, Ignorable: true}), []plugintest.PodCase{
+		{
+			Name:        "unavailable ignorable webhook",
+			Job:         job,
+			Pod:         pod,
+			ExpectedPod: pod,
+		},
+	})
+}
+
+func TestRegisterPlugins(t *testing.T) {
+	testcases := []struct {
+		Name   string
+		Config Config
+	}{
+		{
+			Name:   "plugin of Volcano",
+			Config: Config{Name: "svc", URL: "http://localhost"},
+		},
+		{
+			Name:   "no url",
+			Config: Config{Name: "no-url"},
+		},
+		{
+			Name:   "missing CA file",
+			Config: Config{Name: "missing-ca", URL: "https://localhost", CAFile: "/nonexistent/ca.crt"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if err := RegisterPlugins(&Configuration{Plugins: []Config{testcase.Config}}); err == nil {
+				t.Errorf("expected plugin webhook %s not to be registered", testcase.Config.Name)
+			}
+		})
+	}
+}
@@ -36,6 +36,13 @@ type ActionFn func(job *apis.JobInfo, fn UpdateStatusFn) error
 // KillActionFn kill all Pods of Job with phase not in podRetainPhase.
 type KillActionFn func(job *apis.JobInfo, podRetainPhase PhaseMap, fn UpdateStatusFn) error
 
+// CheckpointActionFn notifies the Pods of Job to checkpoint, and evicts the Pods requested to once Job checkpointed.
+type CheckpointActionFn func(job *apis.JobInfo) error
+
+// CheckpointJobAction is the action of the requests to evict Pods of Job once it checkpointed, it is taken
+// by the job controller itself rather than by the policies of the Job.
+const CheckpointJobAction v1alpha1.Action = "CheckpointJob"
+
 // PodRetainPhaseNone stores no phase.
 var PodRetainPhaseNone = PhaseMap{}
 
@@ -50,6 +57,8 @@ var (
 	SyncJob ActionFn
 	// KillJob kill all Pods of Job with phase not in podRetainPhase.
 	KillJob KillActionFn
+	// CheckpointJob evicts the Pods of Job requested to once it checkpointed.
+	CheckpointJob CheckpointActionFn
 )
 
 // State interface.
@@ -50,6 +50,12 @@ func (ps *pendingState) Execute(action v1alpha1.Action) error {
 			status.State.Phase = vcbatch.Terminating
 			return true
 		})
+	case CheckpointJobAction:
+		// The Pods are evicted once the Job checkpointed, and the Job is synced as by the other actions.
+		if err := CheckpointJob(ps.job); err != nil {
+			return err
+		}
+		fallthrough
 	default:
 		return SyncJob(ps.job, func(status *vcbatch.JobStatus) bool {
 			if ps.job.Job.Spec.MinAvailable <= status.Running+status.Succeeded+status.Failed {
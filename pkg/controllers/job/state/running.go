@@ -51,6 +51,12 @@ func (ps *runningState) Execute(action v1alpha1.Action) error {
 			status.State.Phase = vcbatch.Completing
 			return true
 		})
+	case CheckpointJobAction:
+		// The Pods are evicted once the Job checkpointed, and the Job is synced as by the other actions.
+		if err := CheckpointJob(ps.job); err != nil {
+			return err
+		}
+		fallthrough
 	default:
 		return SyncJob(ps.job, func(status *vcbatch.JobStatus) bool {
 			jobReplicas := TotalTasks(ps.job.Job)
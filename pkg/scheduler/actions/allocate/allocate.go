@@ -26,6 +26,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -170,6 +171,10 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 	stmt := framework.NewStatement(ssn)
 	ph := util.NewPredicateHelper()
 
+	span := ssn.StartSpan("Allocate", tracing.JobAttributes(job)...)
+	defer ssn.EndSpan()
+	allocated, pipelined := 0, 0
+
 	for !tasks.Empty() {
 		task := tasks.Pop().(*api.TaskInfo)
 
@@ -251,6 +256,7 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 				klog.Errorf("Failed to bind Task %v on %v in Session %v, err: %v",
 					task.UID, bestNode.Name, ssn.UID, err)
 			} else {
+				allocated++
 				metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
 				metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
 			}
@@ -266,6 +272,7 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 					klog.Errorf("Failed to pipeline Task %v on %v in Session %v for %v.",
 						task.UID, bestNode.Name, ssn.UID, err)
 				} else {
+					pipelined++
 					metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
 					metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
 				}
@@ -278,13 +285,21 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 		}
 	}
 
+	result := tracing.ResultCommitted
 	if ssn.JobReady(job) {
 		stmt.Commit()
 	} else {
+		result = tracing.ResultPipelined
 		if !ssn.JobPipelined(job) {
+			result = tracing.ResultDiscarded
 			stmt.Discard()
 		}
 	}
+	span.SetAttributes(
+		tracing.AllocatedTasksKey.Int(allocated),
+		tracing.PipelinedTasksKey.Int(pipelined),
+		tracing.AllocateResultKey.String(result),
+	)
 }
 
 func (alloc *Action) predicate(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
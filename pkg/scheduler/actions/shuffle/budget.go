@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shuffle
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// evictionLimits are the maximum numbers of victims evicted in a session, 0 means unlimited.
+type evictionLimits struct {
+	perCycle     int
+	perNode      int
+	perQueue     int
+	perNamespace int
+}
+
+// evictionCounts are the numbers of victims evicted in a session.
+type evictionCounts struct {
+	cycle      int
+	nodes      map[string]int
+	queues     map[api.QueueID]int
+	namespaces map[string]int
+}
+
+func newEvictionCounts() *evictionCounts {
+	return &evictionCounts{
+		nodes:      map[string]int{},
+		queues:     map[api.QueueID]int{},
+		namespaces: map[string]int{},
+	}
+}
+
+func (ec *evictionCounts) add(task *api.TaskInfo, queue api.QueueID) {
+	ec.cycle++
+	ec.nodes[task.NodeName]++
+	ec.queues[queue]++
+	ec.namespaces[task.Namespace]++
+}
+
+// exceeded returns the reason why the task may not be evicted, empty if it may be evicted.
+func (el evictionLimits) exceeded(ec *evictionCounts, task *api.TaskInfo, queue api.QueueID) string {
+	if el.perCycle > 0 && ec.cycle >= el.perCycle {
+		return fmt.Sprintf("%d pods were evicted in this session", ec.cycle)
+	}
+	if el.perNode > 0 && ec.nodes[task.NodeName] >= el.perNode {
+		return fmt.Sprintf("%d pods were evicted from node %s in this session", ec.nodes[task.NodeName], task.NodeName)
+	}
+	if el.perQueue > 0 && ec.queues[queue] >= el.perQueue {
+		return fmt.Sprintf("%d pods were evicted from queue %s in this session", ec.queues[queue], queue)
+	}
+	if el.perNamespace > 0 && ec.namespaces[task.Namespace] >= el.perNamespace {
+		return fmt.Sprintf("%d pods were evicted from namespace %s in this session", ec.namespaces[task.Namespace], task.Namespace)
+	}
+	return ""
+}
+
+// disruptionBudgets keeps track of the disruptions allowed by the pod disruption budgets in a session.
+type disruptionBudgets struct {
+	pdbs    []*policyv1.PodDisruptionBudget
+	allowed []int32
+}
+
+func newDisruptionBudgets(lister policylisters.PodDisruptionBudgetLister) *disruptionBudgets {
+	db := &disruptionBudgets{}
+	pdbs, err := lister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list pdbs: %v", err)
+		return db
+	}
+	db.pdbs = pdbs
+	db.allowed = make([]int32, len(pdbs))
+	for i, pdb := range pdbs {
+		db.allowed[i] = pdb.Status.DisruptionsAllowed
+	}
+	return db
+}
+
+// allows returns whether the pod of the task may be disrupted without violating the budgets, and the
+// budgets matching the pod. The disruption is only counted once committed, after the pod was evicted.
+func (db *disruptionBudgets) allows(task *api.TaskInfo) ([]int, bool) {
+	pod := task.Pod
+	// A pod with no labels will not match any PDB.
+	if pod == nil || len(pod.Labels) == 0 {
+		return nil, true
+	}
+
+	var matched []int
+	for i, pdb := range db.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// The pods in DisruptedPods have already been counted by the API server.
+		if _, found := pdb.Status.DisruptedPods[pod.Name]; found {
+			continue
+		}
+		if db.allowed[i] <= 0 {
+			return nil, false
+		}
+		matched = append(matched, i)
+	}
+	return matched, true
+}
+
+// commit decrements the disruptions allowed by the budgets matching the evicted pod.
+func (db *disruptionBudgets) commit(matched []int) {
+	for _, i := range matched {
+		db.allowed[i]--
+	}
+}
@@ -17,19 +17,50 @@
 package shuffle
 
 import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
 )
 
 const (
 	// Shuffle indicates the action name
 	Shuffle = "shuffle"
+
+	// DryRun is the argument to only record the victims which would be evicted
+	DryRun = "dryRun"
+	// MaxEvictionsPerCycle is the argument of the maximum number of victims evicted in a session
+	MaxEvictionsPerCycle = "maxEvictionsPerCycle"
+	// MaxEvictionsPerNode is the argument of the maximum number of victims evicted from a node in a session
+	MaxEvictionsPerNode = "maxEvictionsPerNode"
+	// MaxEvictionsPerQueue is the argument of the maximum number of victims evicted from a queue in a session
+	MaxEvictionsPerQueue = "maxEvictionsPerQueue"
+	// MaxEvictionsPerNamespace is the argument of the maximum number of victims evicted from a namespace in a session
+	MaxEvictionsPerNamespace = "maxEvictionsPerNamespace"
+	// RespectPDB is the argument to spare the victims whose eviction would violate a pod disruption budget
+	RespectPDB = "respectPDB"
+)
+
+// The results of the victims recorded in the metrics.
+const (
+	resultEvicted = "evicted"
+	resultDryRun  = "dry_run"
+	resultLimited = "limited"
+	resultPDB     = "pdb"
+	resultFailed  = "failed"
 )
 
 // Action defines the action
-type Action struct{}
+type Action struct {
+	dryRun     bool
+	respectPDB bool
+	limits     evictionLimits
+}
 
 // New returns the action instance
 func New() *Action {
@@ -44,11 +75,28 @@ func (shuffle *Action) Name() string {
 // Initialize inits the action
 func (shuffle *Action) Initialize() {}
 
+// parseArguments reads the arguments of the action, the evictions are not limited by default.
+func (shuffle *Action) parseArguments(ssn *framework.Session) {
+	shuffle.dryRun = false
+	shuffle.respectPDB = false
+	shuffle.limits = evictionLimits{}
+
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, shuffle.Name())
+	arguments.GetBool(&shuffle.dryRun, DryRun)
+	arguments.GetBool(&shuffle.respectPDB, RespectPDB)
+	arguments.GetInt(&shuffle.limits.perCycle, MaxEvictionsPerCycle)
+	arguments.GetInt(&shuffle.limits.perNode, MaxEvictionsPerNode)
+	arguments.GetInt(&shuffle.limits.perQueue, MaxEvictionsPerQueue)
+	arguments.GetInt(&shuffle.limits.perNamespace, MaxEvictionsPerNamespace)
+}
+
 // Execute select evictees according given strategies and evict them.
 func (shuffle *Action) Execute(ssn *framework.Session) {
 	klog.V(5).Infoln("Enter Shuffle ...")
 	defer klog.V(5).Infoln("Leaving Shuffle ...")
 
+	shuffle.parseArguments(ssn)
+
 	// select pods that may be evicted
 	tasks := make([]*api.TaskInfo, 0)
 	for _, jobInfo := range ssn.Jobs {
@@ -60,13 +108,72 @@ func (shuffle *Action) Execute(ssn *framework.Session) {
 	}
 
 	// Evict target workloads
-	victims := ssn.VictimTasks(tasks)
+	shuffle.evict(ssn, ssn.VictimTasks(tasks))
+}
+
+// evict evicts the victims within the limits, the victims of the lowest priority first.
+func (shuffle *Action) evict(ssn *framework.Session, victims map[*api.TaskInfo]bool) {
+	sorted := make([]*api.TaskInfo, 0, len(victims))
 	for victim := range victims {
+		sorted = append(sorted, victim)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var pdbs *disruptionBudgets
+	if shuffle.respectPDB {
+		pdbs = newDisruptionBudgets(ssn.InformerFactory().Policy().V1().PodDisruptionBudgets().Lister())
+	}
+	evictions := newEvictionCounts()
+	for _, victim := range sorted {
+		queue := api.QueueID("")
+		if job, found := ssn.Jobs[victim.Job]; found {
+			queue = job.Queue
+		}
+		if reason := shuffle.limits.exceeded(evictions, victim, queue); reason != "" {
+			klog.V(3).Infof("Pod <%s/%s> of job %s is not evicted, %s.", victim.Namespace, victim.Name, victim.Job, reason)
+			metrics.RegisterShuffleEviction(resultLimited)
+			continue
+		}
+		var matched []int
+		if pdbs != nil {
+			var allowed bool
+			if matched, allowed = pdbs.allows(victim); !allowed {
+				klog.V(3).Infof("Pod <%s/%s> of job %s is not evicted, its eviction would violate a pod disruption budget.", victim.Namespace, victim.Name, victim.Job)
+				metrics.RegisterShuffleEviction(resultPDB)
+				continue
+			}
+		}
+
+		if shuffle.dryRun {
+			klog.V(3).Infof("pod %s from namespace %s and job %s would be evicted.", victim.Name, victim.Namespace, string(victim.Job))
+			ssn.RecordPodEvent(victim, v1.EventTypeNormal, "ShuffleDryRun", fmt.Sprintf("Pod would be evicted from node %s by the shuffle action", victim.NodeName))
+			evictions.add(victim, queue)
+			if pdbs != nil {
+				pdbs.commit(matched)
+			}
+			metrics.RegisterShuffleEviction(resultDryRun)
+			continue
+		}
+
 		klog.V(3).Infof("pod %s from namespace %s and job %s will be evicted.\n", victim.Name, victim.Namespace, string(victim.Job))
 		if err := ssn.Evict(victim, "shuffle"); err != nil {
 			klog.Errorf("Failed to evict Task <%s/%s>: %v\n", victim.Namespace, victim.Name, err)
+			metrics.RegisterShuffleEviction(resultFailed)
 			continue
 		}
+		evictions.add(victim, queue)
+		if pdbs != nil {
+			pdbs.commit(matched)
+		}
+		metrics.RegisterShuffleEviction(resultEvicted)
 	}
 }
 
@@ -21,6 +21,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -40,41 +44,119 @@ func TestShuffle(t *testing.T) {
 	ctl := gomock.NewController(t)
 	fakePlugin := mock_framework.NewMockPlugin(ctl)
 	fakePlugin.EXPECT().Name().AnyTimes().Return("fake")
-	fakePlugin.EXPECT().OnSessionOpen(gomock.Any()).Return()
-	fakePlugin.EXPECT().OnSessionClose(gomock.Any()).Return()
+	fakePlugin.EXPECT().OnSessionOpen(gomock.Any()).AnyTimes().Return()
+	fakePlugin.EXPECT().OnSessionClose(gomock.Any()).AnyTimes().Return()
 	fakePluginBuilder := func(arguments framework.Arguments) framework.Plugin {
 		return fakePlugin
 	}
 
 	plugins := map[string]framework.PluginBuilder{"fake": fakePluginBuilder}
 
-	tests := []uthelper.TestCommonStruct{
+	nodes := []*v1.Node{
+		util.BuildNode("node1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		util.BuildNode("node2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+	}
+	queues := []*schedulingv1beta1.Queue{
+		util.BuildQueue("default", 1, nil),
+		util.BuildQueue("other", 1, nil),
+	}
+	podGroups := []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg1", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg2", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg3", "test", "other", 0, nil, schedulingv1beta1.PodGroupRunning),
+	}
+	// The pods are built for every case as they are updated by the evictions.
+	pods := func() []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPodWithPriority("test", "pod1-1", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &lowPriority),
+			util.BuildPodWithPriority("test", "pod1-2", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &highPriority),
+			util.BuildPodWithPriority("test", "pod1-3", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &highPriority),
+			util.BuildPodWithPriority("test", "pod2-1", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg2", make(map[string]string), make(map[string]string), &lowPriority),
+			util.BuildPodWithPriority("test", "pod2-2", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg2", make(map[string]string), make(map[string]string), &highPriority),
+			util.BuildPodWithPriority("test", "pod3-1", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg3", make(map[string]string), make(map[string]string), &lowPriority),
+			util.BuildPodWithPriority("test", "pod3-2", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg3", make(map[string]string), make(map[string]string), &highPriority),
+		}
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments framework.Arguments
+	}{
 		{
-			Name:    "select pods with low priority and evict them",
-			Plugins: plugins,
-			Nodes: []*v1.Node{
-				util.BuildNode("node1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
-				util.BuildNode("node2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "select pods with low priority and evict them",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 3,
+				ExpectEvicted:  []string{"test/pod1-1", "test/pod2-1", "test/pod3-1"},
 			},
-			Queues: []*schedulingv1beta1.Queue{
-				util.BuildQueue("default", 1, nil),
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "only record the victims in dry run",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 0,
 			},
-			PodGroups: []*schedulingv1beta1.PodGroup{
-				util.BuildPodGroup("pg1", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
-				util.BuildPodGroup("pg2", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
-				util.BuildPodGroup("pg3", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
+			arguments: framework.Arguments{DryRun: true},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "limit the evictions per cycle",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 2,
+				ExpectEvicted:  []string{"test/pod1-1", "test/pod2-1"},
 			},
-			Pods: []*v1.Pod{
-				util.BuildPodWithPriority("test", "pod1-1", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &lowPriority),
-				util.BuildPodWithPriority("test", "pod1-2", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &highPriority),
-				util.BuildPodWithPriority("test", "pod1-3", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &highPriority),
-				util.BuildPodWithPriority("test", "pod2-1", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg2", make(map[string]string), make(map[string]string), &lowPriority),
-				util.BuildPodWithPriority("test", "pod2-2", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg2", make(map[string]string), make(map[string]string), &highPriority),
-				util.BuildPodWithPriority("test", "pod3-1", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg3", make(map[string]string), make(map[string]string), &lowPriority),
-				util.BuildPodWithPriority("test", "pod3-2", "node2", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg3", make(map[string]string), make(map[string]string), &highPriority),
+			arguments: framework.Arguments{MaxEvictionsPerCycle: 2},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "limit the evictions per node",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 2,
+				ExpectEvicted:  []string{"test/pod1-1", "test/pod3-1"},
 			},
-			ExpectEvictNum: 3,
-			ExpectEvicted:  []string{"test/pod1-1", "test/pod2-1", "test/pod3-1"},
+			arguments: framework.Arguments{MaxEvictionsPerNode: 1},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "limit the evictions per queue",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 2,
+				ExpectEvicted:  []string{"test/pod1-1", "test/pod3-1"},
+			},
+			arguments: framework.Arguments{MaxEvictionsPerQueue: 1},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "limit the evictions per namespace",
+				Plugins:        plugins,
+				Nodes:          nodes,
+				Queues:         queues,
+				PodGroups:      podGroups,
+				Pods:           pods(),
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"test/pod1-1"},
+			},
+			arguments: framework.Arguments{MaxEvictionsPerNamespace: 1},
 		},
 	}
 	shuffle := New()
@@ -109,7 +191,8 @@ func TestShuffle(t *testing.T) {
 		}
 
 		t.Run(test.Name, func(t *testing.T) {
-			ssn := test.RegisterSession(tiers, nil)
+			configurations := []conf.Configuration{{Name: Shuffle, Arguments: test.arguments}}
+			ssn := test.RegisterSession(tiers, configurations)
 			defer test.Close()
 			ssn.AddVictimTasksFns("fake", fakePluginVictimFns())
 			test.Run([]framework.Action{shuffle})
@@ -119,3 +202,43 @@ func TestShuffle(t *testing.T) {
 		})
 	}
 }
+
+func TestDisruptionBudgets(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "pdb1"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: 1,
+			DisruptedPods:      map[string]metav1.Time{"pod3": {}},
+		},
+	})
+	pdbs := newDisruptionBudgets(policylisters.NewPodDisruptionBudgetLister(indexer))
+
+	task := func(name string, labels map[string]string) *api.TaskInfo {
+		return api.NewTaskInfo(util.BuildPod("test", name, "node1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", labels, make(map[string]string)))
+	}
+	for _, c := range []struct {
+		task *api.TaskInfo
+		// evicted commits the disruption, the failed evictions are not counted.
+		evicted bool
+		expect  bool
+	}{
+		{task: task("pod1", map[string]string{"app": "web"}), evicted: false, expect: true},
+		{task: task("pod1", map[string]string{"app": "web"}), evicted: true, expect: true},
+		{task: task("pod2", map[string]string{"app": "web"}), evicted: true, expect: false},
+		{task: task("pod3", map[string]string{"app": "web"}), evicted: true, expect: true},
+		{task: task("pod4", map[string]string{"app": "db"}), evicted: true, expect: true},
+		{task: task("pod5", nil), evicted: true, expect: true},
+	} {
+		matched, got := pdbs.allows(c.task)
+		if got != c.expect {
+			t.Errorf("expected the disruption of %s to be allowed: %v, got %v", c.task.Name, c.expect, got)
+		}
+		if got && c.evicted {
+			pdbs.commit(matched)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// timeWindowLayout is the layout of the start and end of a time window
+const timeWindowLayout = "15:04"
+
+// CapacityWindow is the capacity of a queue during a time window, the fields which
+// are not set keep the values of the queue spec.
+type CapacityWindow struct {
+	Name string `json:"name"`
+	// Window is the time of the day the window is active during, e.g. 09:00-18:00 or 22:00-06:00
+	Window string `json:"window"`
+	// Days are the days of the week the window starts on, e.g. Mon, all days if empty
+	Days       []string        `json:"days,omitempty"`
+	Weight     int32           `json:"weight,omitempty"`
+	Capability v1.ResourceList `json:"capability,omitempty"`
+	Deserved   v1.ResourceList `json:"deserved,omitempty"`
+	Guarantee  v1.ResourceList `json:"guarantee,omitempty"`
+
+	start, end time.Time
+	days       map[time.Weekday]bool
+}
+
+// ParseCapacitySchedule parses the capacity windows in the capacity schedule annotation, as yaml or json.
+func ParseCapacitySchedule(raw string) ([]*CapacityWindow, error) {
+	var windows []*CapacityWindow
+	if err := yaml.Unmarshal([]byte(raw), &windows); err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		if err := w.parse(); err != nil {
+			return nil, fmt.Errorf("capacity window %q: %v", w.Name, err)
+		}
+	}
+	return windows, nil
+}
+
+func (w *CapacityWindow) parse() error {
+	values := strings.Split(strings.TrimSpace(w.Window), "-")
+	if len(values) != 2 {
+		return fmt.Errorf("window %q format error, expected hh:mm-hh:mm", w.Window)
+	}
+	var err error
+	if w.start, err = time.Parse(timeWindowLayout, strings.TrimSpace(values[0])); err != nil {
+		return err
+	}
+	if w.end, err = time.Parse(timeWindowLayout, strings.TrimSpace(values[1])); err != nil {
+		return err
+	}
+
+	if len(w.Days) == 0 {
+		return nil
+	}
+	w.days = map[time.Weekday]bool{}
+	for _, day := range w.Days {
+		found := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(day, weekday.String()) || strings.EqualFold(day, weekday.String()[:3]) {
+				w.days[weekday] = true
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown day %q", day)
+		}
+	}
+	return nil
+}
+
+// Active returns whether the window is active at the time. A window whose end is
+// not after its start ends on the next day.
+func (w *CapacityWindow) Active(now time.Time) bool {
+	start := time.Date(now.Year(), now.Month(), now.Day(), w.start.Hour(), w.start.Minute(), 0, 0, now.Location())
+	end := time.Date(now.Year(), now.Month(), now.Day(), w.end.Hour(), w.end.Minute(), 0, 0, now.Location())
+	if start.Before(end) {
+		return !now.Before(start) && now.Before(end) && w.activeOn(now.Weekday())
+	}
+	// The window ends on the next day, it is active since its start today or until its end if it started yesterday.
+	if !now.Before(start) {
+		return w.activeOn(now.Weekday())
+	}
+	return now.Before(end) && w.activeOn(now.AddDate(0, 0, -1).Weekday())
+}
+
+func (w *CapacityWindow) activeOn(day time.Weekday) bool {
+	return len(w.days) == 0 || w.days[day]
+}
+
+// ActiveCapacityWindow returns the first window of the capacity schedule of the queue
+// active at the time, nil if none is.
+func (q *QueueInfo) ActiveCapacityWindow(now time.Time) *CapacityWindow {
+	for _, w := range q.CapacitySchedule {
+		if w.Active(now) {
+			return w
+		}
+	}
+	return nil
+}
+
+// WithCapacityWindow returns a copy of the queue with the capacity of the window.
+func (q *QueueInfo) WithCapacityWindow(w *CapacityWindow) *QueueInfo {
+	queue := q.Clone()
+	queue.Queue = q.Queue.DeepCopy()
+	if w.Weight > 0 {
+		queue.Weight = w.Weight
+		queue.Queue.Spec.Weight = w.Weight
+	}
+	if w.Capability != nil {
+		queue.Queue.Spec.Capability = w.Capability.DeepCopy()
+	}
+	if w.Deserved != nil {
+		queue.Queue.Spec.Deserved = w.Deserved.DeepCopy()
+	}
+	if w.Guarantee != nil {
+		queue.Queue.Spec.Guarantee.Resource = w.Guarantee.DeepCopy()
+	}
+	return queue
+}
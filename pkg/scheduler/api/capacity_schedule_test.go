@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+)
+
+func TestCapacitySchedule(t *testing.T) {
+	queue := NewQueueInfo(&scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu",
+			Annotations: map[string]string{CapacityScheduleAnnotation: `
+- name: business-hours
+  window: 09:00-18:00
+  days: [Mon, Tue, Wed, Thu, Fri]
+  capability:
+    nvidia.com/gpu: 4
+- name: nights
+  window: 22:00-06:00
+  weight: 4
+  deserved:
+    nvidia.com/gpu: 16
+`},
+		},
+		Spec: scheduling.QueueSpec{
+			Weight:     1,
+			Capability: v1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+		},
+	})
+	if len(queue.CapacitySchedule) != 2 {
+		t.Fatalf("expected 2 capacity windows, got %d", len(queue.CapacitySchedule))
+	}
+
+	// 2024-01-01 is a Monday.
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.Local)
+	}
+	for _, c := range []struct {
+		time   time.Time
+		expect string
+	}{
+		{time: at(1, 10), expect: "business-hours"},
+		{time: at(6, 10), expect: ""},
+		{time: at(1, 18), expect: ""},
+		{time: at(1, 23), expect: "nights"},
+		{time: at(2, 5), expect: "nights"},
+		{time: at(7, 5), expect: "nights"},
+		{time: at(2, 6), expect: ""},
+	} {
+		got := ""
+		if window := queue.ActiveCapacityWindow(c.time); window != nil {
+			got = window.Name
+		}
+		if got != c.expect {
+			t.Errorf("expected window %q to be active at %v, got %q", c.expect, c.time, got)
+		}
+	}
+
+	nights := queue.WithCapacityWindow(queue.ActiveCapacityWindow(at(1, 23)))
+	if nights.Weight != 4 || nights.Queue.Spec.Deserved.Name("nvidia.com/gpu", resource.DecimalSI).Value() != 16 ||
+		nights.Queue.Spec.Capability.Name("nvidia.com/gpu", resource.DecimalSI).Value() != 8 {
+		t.Errorf("unexpected queue with capacity window nights: weight %d, spec %v", nights.Weight, nights.Queue.Spec)
+	}
+	if queue.Weight != 1 || queue.Queue.Spec.Deserved != nil {
+		t.Errorf("expected the queue not to be changed, got weight %d, spec %v", queue.Weight, queue.Queue.Spec)
+	}
+
+	if _, err := ParseCapacitySchedule(`[{"name": "invalid", "window": "09:00"}]`); err == nil {
+		t.Errorf("expected an error for a window without end")
+	}
+	if _, err := ParseCapacitySchedule(`[{"name": "invalid", "window": "09:00-18:00", "days": ["Someday"]}]`); err == nil {
+		t.Errorf("expected an error for an unknown day")
+	}
+}
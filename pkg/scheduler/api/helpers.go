@@ -35,7 +35,8 @@ func PodKey(pod *v1.Pod) TaskID {
 func getTaskStatus(pod *v1.Pod) TaskStatus {
 	switch pod.Status.Phase {
 	case v1.PodRunning:
-		if pod.DeletionTimestamp != nil {
+		// The pods waiting for their job to checkpoint are released once it checkpointed.
+		if pod.DeletionTimestamp != nil || len(pod.Annotations[CheckpointRequestedAnnotation]) != 0 {
 			return Releasing
 		}
 
@@ -113,12 +113,45 @@ type NodeUsage struct {
 	MetricsTime time.Time
 	CPUUsageAvg map[string]float64
 	MEMUsageAvg map[string]float64
+	// MetricsAvg is the average usage of the other metrics of the node, keyed by metric name and period.
+	MetricsAvg map[string]map[string]float64
+	// Usage is the usage of the metrics of the node summarized by the other usage types, e.g. "max"
+	// or "p99", keyed by usage type, metric name and period.
+	Usage map[string]map[string]map[string]float64
+}
+
+// UsageOf returns the usage of the metric over the period summarized by the usage type,
+// the average usage if the usage type is empty or "average".
+func (nu *NodeUsage) UsageOf(usageType, metric, period string) (float64, bool) {
+	if usageType == "" || usageType == "average" {
+		return nu.UsageAvg(metric, period)
+	}
+	value, found := nu.Usage[usageType][metric][period]
+	return value, found
+}
+
+// UsageAvg returns the average usage of the metric over the period, "cpu" and "memory"
+// are read from CPUUsageAvg and MEMUsageAvg.
+func (nu *NodeUsage) UsageAvg(metric, period string) (float64, bool) {
+	var usage map[string]float64
+	switch metric {
+	case "cpu":
+		usage = nu.CPUUsageAvg
+	case "memory":
+		usage = nu.MEMUsageAvg
+	default:
+		usage = nu.MetricsAvg[metric]
+	}
+	value, found := usage[period]
+	return value, found
 }
 
 func (nu *NodeUsage) DeepCopy() *NodeUsage {
 	newUsage := &NodeUsage{
 		CPUUsageAvg: make(map[string]float64),
 		MEMUsageAvg: make(map[string]float64),
+		MetricsAvg:  make(map[string]map[string]float64, len(nu.MetricsAvg)),
+		Usage:       make(map[string]map[string]map[string]float64, len(nu.Usage)),
 	}
 	newUsage.MetricsTime = nu.MetricsTime
 	for k, v := range nu.CPUUsageAvg {
@@ -127,6 +160,21 @@ func (nu *NodeUsage) DeepCopy() *NodeUsage {
 	for k, v := range nu.MEMUsageAvg {
 		newUsage.MEMUsageAvg[k] = v
 	}
+	for metric, usage := range nu.MetricsAvg {
+		newUsage.MetricsAvg[metric] = make(map[string]float64, len(usage))
+		for k, v := range usage {
+			newUsage.MetricsAvg[metric][k] = v
+		}
+	}
+	for usageType, metrics := range nu.Usage {
+		newUsage.Usage[usageType] = make(map[string]map[string]float64, len(metrics))
+		for metric, usage := range metrics {
+			newUsage.Usage[usageType][metric] = make(map[string]float64, len(usage))
+			for k, v := range usage {
+				newUsage.Usage[usageType][metric][k] = v
+			}
+		}
+	}
 	return newUsage
 }
 
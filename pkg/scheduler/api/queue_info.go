@@ -18,6 +18,7 @@ package api
 
 import (
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -40,13 +41,15 @@ type QueueInfo struct {
 	// Hierarchy is a list of node name along the
 	// path from the root to the node itself.
 	Hierarchy string
+	// CapacitySchedule is the list of time windows the capacity of the queue is changed during.
+	CapacitySchedule []*CapacityWindow
 
 	Queue *scheduling.Queue
 }
 
 // NewQueueInfo creates new queueInfo object
 func NewQueueInfo(queue *scheduling.Queue) *QueueInfo {
-	return &QueueInfo{
+	queueInfo := &QueueInfo{
 		UID:  QueueID(queue.Name),
 		Name: queue.Name,
 
@@ -56,6 +59,15 @@ func NewQueueInfo(queue *scheduling.Queue) *QueueInfo {
 
 		Queue: queue,
 	}
+	if schedule, found := queue.Annotations[CapacityScheduleAnnotation]; found {
+		windows, err := ParseCapacitySchedule(schedule)
+		if err != nil {
+			klog.Errorf("Failed to parse the capacity schedule of queue <%s>, ignore it: %v", queue.Name, err)
+		} else {
+			queueInfo.CapacitySchedule = windows
+		}
+	}
+	return queueInfo
 }
 
 // Clone is used to clone queueInfo object
@@ -67,6 +79,8 @@ func (q *QueueInfo) Clone() *QueueInfo {
 		Hierarchy: q.Hierarchy,
 		Weights:   q.Weights,
 		Queue:     q.Queue,
+
+		CapacitySchedule: q.CapacitySchedule,
 	}
 }
 
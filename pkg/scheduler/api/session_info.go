@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DecisionType is the kind of a scheduling decision.
+type DecisionType string
+
+const (
+	// BindDecision means the task is sent to the cache to be bound to the node.
+	BindDecision DecisionType = "Bind"
+	// PipelineDecision means the task waits for resources released on the node.
+	PipelineDecision DecisionType = "Pipeline"
+	// EvictDecision means the task is evicted from the node.
+	EvictDecision DecisionType = "Evict"
+)
+
+// SessionDecision is a placement or eviction of a task made by an action.
+type SessionDecision struct {
+	Action    string       `json:"action"`
+	Type      DecisionType `json:"type"`
+	Job       JobID        `json:"job"`
+	Task      string       `json:"task"`
+	Node      string       `json:"node"`
+	Reason    string       `json:"reason,omitempty"`
+	Timestamp metav1.Time  `json:"timestamp"`
+}
+
+// SessionInfo summarizes the decisions of one scheduling session.
+type SessionInfo struct {
+	UID       types.UID         `json:"uid"`
+	StartTime metav1.Time       `json:"startTime"`
+	EndTime   metav1.Time       `json:"endTime"`
+	Decisions []SessionDecision `json:"decisions"`
+	// Unschedulable holds the fit error of jobs that were left with pending tasks.
+	Unschedulable map[JobID]string `json:"unschedulable,omitempty"`
+	// Traces holds the plugin verdicts of traced tasks.
+	Traces []*TaskTrace `json:"traces,omitempty"`
+}
+
+// TaskTrace records how the plugins judged the nodes a task was checked against.
+type TaskTrace struct {
+	Job   JobID                 `json:"job"`
+	Task  string                `json:"task"`
+	Nodes map[string]*NodeTrace `json:"nodes"`
+}
+
+// NodeTrace holds the predicate verdict and the scores of each plugin for a node.
+type NodeTrace struct {
+	Predicates map[string]PredicateTrace `json:"predicates,omitempty"`
+	Scores     map[string]ScoreTrace     `json:"scores,omitempty"`
+}
+
+// PredicateTrace is the verdict of the PredicateFn of a plugin.
+type PredicateTrace struct {
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScoreTrace holds the scores a plugin gave to a node by kind of function.
+type ScoreTrace struct {
+	NodeOrder      float64 `json:"nodeOrder,omitempty"`
+	BatchNodeOrder float64 `json:"batchNodeOrder,omitempty"`
+	NodeReduce     float64 `json:"nodeReduce,omitempty"`
+}
+
+// Total returns the score the plugin added to the node.
+func (s ScoreTrace) Total() float64 {
+	return s.NodeOrder + s.BatchNodeOrder + s.NodeReduce
+}
@@ -91,6 +91,15 @@ func (f *FitErrors) Error() string {
 	return reasonMsg
 }
 
+// Reasons returns the reasons of all the nodes the task does not fit on
+func (f *FitErrors) Reasons() []string {
+	var reasons []string
+	for _, node := range f.nodes {
+		reasons = append(reasons, node.Reasons...)
+	}
+	return reasons
+}
+
 // FitError describe the reason why task could not fit that node
 type FitError struct {
 	taskNamespace string
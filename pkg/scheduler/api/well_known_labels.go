@@ -40,6 +40,41 @@ const (
 	// OfflineJobEvicting node will not schedule pod due to offline job evicting
 	OfflineJobEvicting = "volcano.sh/offline-job-evicting"
 
+	// SchedulingTraceAnnotation on a PodGroup enables the scheduling trace of its tasks when set to "true"
+	SchedulingTraceAnnotation = "volcano.sh/scheduling-trace"
+
+	// SchedulingProfileAnnotation on a PodGroup or a Queue selects the scheduling profile of its jobs,
+	// the annotation on the PodGroup takes precedence
+	SchedulingProfileAnnotation = "volcano.sh/scheduling-profile"
+
+	// CapacityScheduleAnnotation on a Queue declares the time windows its capacity is changed during
+	CapacityScheduleAnnotation = "volcano.sh/capacity-schedule"
+	// ActiveCapacityWindowAnnotation on a Queue is set by the scheduler to the name of its active capacity window
+	ActiveCapacityWindowAnnotation = "volcano.sh/active-capacity-window"
+
+	// CheckpointPolicyAnnotation on a Job, and its PodGroup, makes the evictions of its running pods wait for
+	// the job to checkpoint. The pods are notified with "signal", "exec" or "http"
+	CheckpointPolicyAnnotation = "volcano.sh/checkpoint-policy"
+	// CheckpointGracePeriodAnnotation on a Job is the longest duration the evictions wait for the job to checkpoint
+	CheckpointGracePeriodAnnotation = "volcano.sh/checkpoint-grace-period"
+	// CheckpointSignalAnnotation on a Job is the signal sent to the pods by the "signal" policy
+	CheckpointSignalAnnotation = "volcano.sh/checkpoint-signal"
+	// CheckpointCommandAnnotation on a Job is the shell command run in the pods by the "exec" policy
+	CheckpointCommandAnnotation = "volcano.sh/checkpoint-command"
+	// CheckpointEndpointAnnotation on a Job is the port and path of the pods posted to by the "http" policy, e.g. ":8080/checkpoint"
+	CheckpointEndpointAnnotation = "volcano.sh/checkpoint-endpoint"
+	// CheckpointContainerAnnotation on a Job is the container of the pods notified, the first container by default
+	CheckpointContainerAnnotation = "volcano.sh/checkpoint-container"
+	// CheckpointTasksAnnotation on a Job is the comma separated tasks whose pods are notified, all the tasks by default
+	CheckpointTasksAnnotation = "volcano.sh/checkpoint-tasks"
+	// CheckpointRequestedAnnotation on a Pod is the time the scheduler requested to evict it once its job checkpointed
+	CheckpointRequestedAnnotation = "volcano.sh/checkpoint-requested"
+	// CheckpointNotifiedAnnotation on a Pod is the time it was notified to checkpoint
+	CheckpointNotifiedAnnotation = "volcano.sh/checkpoint-notified"
+	// CheckpointDoneAnnotation on a Pod is set once it checkpointed, by the pod itself or by the job controller
+	// when the notification returned successfully
+	CheckpointDoneAnnotation = "volcano.sh/checkpoint-done"
+
 	// topologyDecisionAnnotation is the key of topology decision about pod request resource
 	topologyDecisionAnnotation = "volcano.sh/topology-decision"
 )
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -31,6 +32,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -318,11 +320,34 @@ func (su *defaultStatusUpdater) UpdateQueueStatus(queue *schedulingapi.QueueInfo
 		return err
 	}
 
-	_, err := su.vcclient.SchedulingV1beta1().Queues().UpdateStatus(context.TODO(), newQueue, metav1.UpdateOptions{})
+	updated, err := su.vcclient.SchedulingV1beta1().Queues().UpdateStatus(context.TODO(), newQueue, metav1.UpdateOptions{})
 	if err != nil {
 		klog.Errorf("error occurred in updating Queue <%s>: %s", newQueue.Name, err.Error())
 		return err
 	}
+
+	// The metadata is not updated with the status, the active capacity window is patched aside.
+	window := newQueue.Annotations[schedulingapi.ActiveCapacityWindowAnnotation]
+	if updated.Annotations[schedulingapi.ActiveCapacityWindowAnnotation] == window {
+		return nil
+	}
+	var value interface{}
+	if window != "" {
+		value = window
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{schedulingapi.ActiveCapacityWindowAnnotation: value},
+		},
+	})
+	if err != nil {
+		klog.Errorf("error occurred in building the active capacity window patch of Queue <%s>: %s", newQueue.Name, err.Error())
+		return nil
+	}
+	// The status is updated already, failing to record the window is only logged, it is patched again at the next session.
+	if _, err := su.vcclient.SchedulingV1beta1().Queues().Patch(context.TODO(), newQueue.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.Errorf("error occurred in updating the active capacity window of Queue <%s>: %s", newQueue.Name, err.Error())
+	}
 	return nil
 }
 
@@ -869,9 +894,17 @@ func (sc *SchedulerCache) Evict(taskInfo *schedulingapi.TaskInfo, reason string)
 	}
 
 	p := task.Pod
+	// The running pods of the jobs with a checkpoint policy are evicted by the job controller once the job checkpointed.
+	checkpoint := originalStatus == schedulingapi.Running && job.PodGroup != nil &&
+		len(job.PodGroup.Annotations[schedulingapi.CheckpointPolicyAnnotation]) != 0
 
 	go func() {
-		err := sc.Evictor.Evict(p, reason)
+		var err error
+		if checkpoint {
+			err = sc.requestCheckpoint(p, reason)
+		} else {
+			err = sc.Evictor.Evict(p, reason)
+		}
 		if err != nil {
 			sc.resyncTask(task)
 		}
@@ -892,6 +925,31 @@ func (sc *SchedulerCache) Evict(taskInfo *schedulingapi.TaskInfo, reason string)
 	return nil
 }
 
+// requestCheckpoint requests the job controller to evict the pod once its job checkpointed.
+func (sc *SchedulerCache) requestCheckpoint(p *v1.Pod, reason string) error {
+	if len(p.Annotations[schedulingapi.CheckpointRequestedAnnotation]) != 0 {
+		return nil
+	}
+	klog.V(3).Infof("Requesting checkpoint of pod %v/%v before eviction, because of %v", p.Namespace, p.Name, reason)
+	sc.Recorder.Eventf(p, v1.EventTypeWarning, "Evict", "Pod is evicted once its job checkpointed, because of %v", reason)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				schedulingapi.CheckpointRequestedAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := sc.kubeClient.CoreV1().Pods(p.Namespace).Patch(context.TODO(), p.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.Errorf("Failed to request checkpoint of pod <%v/%v>: %v", p.Namespace, p.Name, err)
+		return err
+	}
+	return nil
+}
+
 // Bind binds task to the target host.
 func (sc *SchedulerCache) Bind(tasks []*schedulingapi.TaskInfo) {
 	tmp := time.Now()
@@ -1505,7 +1563,7 @@ func (sc *SchedulerCache) GetMetricsData() {
 		return
 	}
 
-	client, err := source.NewMetricsClient(sc.restConfig, sc.metricsConf)
+	client, err := source.NewMetricsClient(sc.restConfig, sc.nodeInformer.Lister(), sc.metricsConf)
 	if err != nil {
 		klog.Errorf("Error creating client: %v\n", err)
 		return
@@ -1516,7 +1574,7 @@ func (sc *SchedulerCache) GetMetricsData() {
 	sc.Mutex.Lock()
 
 	for _, nodeName := range sc.NodeList {
-		nodeMetricsMap[nodeName] = &source.NodeMetrics{}
+		nodeMetricsMap[nodeName] = source.NewNodeMetrics()
 	}
 	sc.Mutex.Unlock()
 
@@ -1533,25 +1591,61 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 
+	alpha := source.EWMAAlpha(sc.metricsConf)
 	for nodeName, nodeMetric := range usageInfo {
 		nodeUsage := &schedulingapi.NodeUsage{
 			CPUUsageAvg: make(map[string]float64),
 			MEMUsageAvg: make(map[string]float64),
+			MetricsAvg:  make(map[string]map[string]float64),
+			Usage:       make(map[string]map[string]map[string]float64),
 		}
 		nodeUsage.MetricsTime = nodeMetric.MetricsTime
-		nodeUsage.CPUUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.CPU
-		nodeUsage.MEMUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.Memory
+		for metric, usage := range nodeMetric.Metrics {
+			switch metric {
+			case source.MetricCPU:
+				nodeUsage.CPUUsageAvg[source.NODE_METRICS_PERIOD] = usage
+			case source.MetricMemory:
+				nodeUsage.MEMUsageAvg[source.NODE_METRICS_PERIOD] = usage
+			default:
+				nodeUsage.MetricsAvg[metric] = map[string]float64{source.NODE_METRICS_PERIOD: usage}
+			}
+		}
+		for usageType, metrics := range nodeMetric.Usage {
+			nodeUsage.Usage[usageType] = make(map[string]map[string]float64, len(metrics))
+			for metric, usage := range metrics {
+				nodeUsage.Usage[usageType][metric] = map[string]float64{source.NODE_METRICS_PERIOD: usage}
+			}
+		}
 
 		nodeInfo, ok := sc.Nodes[nodeName]
 		if !ok {
 			klog.Errorf("The information about node %s cannot be found in the cache.", nodeName)
 			continue
 		}
+		setUsageEWMA(nodeUsage, nodeInfo.ResourceUsage, nodeMetric.Metrics, alpha)
 		klog.V(5).Infof("node: %s, ResourceUsage: %+v => %+v", nodeName, *nodeInfo.ResourceUsage, nodeUsage)
 		nodeInfo.ResourceUsage = nodeUsage
 	}
 }
 
+// setUsageEWMA sets the exponentially weighted moving average of the average usage of the metrics,
+// alpha is the weight of the latest average usage. The previous ewma is kept if the metrics were not
+// measured again since the previous collection, e.g. the annotations of the node were not updated.
+func setUsageEWMA(nodeUsage, previous *schedulingapi.NodeUsage, metrics map[string]float64, alpha float64) {
+	ewma := make(map[string]map[string]float64, len(metrics))
+	for metric, usage := range metrics {
+		if previousEWMA, found := previous.UsageOf(source.UsageTypeEWMA, metric, source.NODE_METRICS_PERIOD); found {
+			if nodeUsage.MetricsTime.Equal(previous.MetricsTime) {
+				usage = previousEWMA
+			} else {
+				usage = alpha*usage + (1-alpha)*previousEWMA
+			}
+		}
+		ewma[metric] = map[string]float64{source.NODE_METRICS_PERIOD: usage}
+	}
+	nodeUsage.Usage[source.UsageTypeEWMA] = ewma
+}
+
 // createImageStateSummary returns a summarizing snapshot of the given image's state.
 func (sc *SchedulerCache) createImageStateSummary(state *imageState) *framework.ImageStateSummary {
 	return &framework.ImageStateSummary{
@@ -25,10 +25,18 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	fakevcclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics/source"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -339,3 +347,133 @@ func TestBindTasks(t *testing.T) {
 		t.Fatalf("succesfully binding task should have 1 event")
 	}
 }
+
+func TestSetMetricsDataEWMA(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.SetMetricsConf(map[string]string{"ewma.alpha": "0.5"})
+	sc.AddOrUpdateNode(buildNode("n1", api.BuildResourceList("2000m", "10G")))
+
+	metricsTime := time.Now()
+	for _, sample := range []struct {
+		cpu         float64
+		metricsTime time.Time
+		expected    float64
+	}{
+		{cpu: 40, metricsTime: metricsTime, expected: 40},
+		{cpu: 80, metricsTime: metricsTime.Add(time.Minute), expected: 60},
+		// The metrics are not measured again, the ewma is kept.
+		{cpu: 80, metricsTime: metricsTime.Add(time.Minute), expected: 60},
+		{cpu: 20, metricsTime: metricsTime.Add(2 * time.Minute), expected: 40},
+	} {
+		sc.setMetricsData(map[string]*source.NodeMetrics{
+			"n1": {
+				MetricsTime: sample.metricsTime,
+				Metrics:     map[string]float64{source.MetricCPU: sample.cpu},
+				Usage:       map[string]map[string]float64{source.UsageTypeMax: {source.MetricCPU: 100}},
+			},
+		})
+		usage := sc.Nodes["n1"].ResourceUsage
+		if ewma, _ := usage.UsageOf(source.UsageTypeEWMA, source.MetricCPU, source.NODE_METRICS_PERIOD); ewma != sample.expected {
+			t.Errorf("expected cpu ewma %v, got %v", sample.expected, ewma)
+		}
+		if maxUsage, _ := usage.UsageOf(source.UsageTypeMax, source.MetricCPU, source.NODE_METRICS_PERIOD); maxUsage != 100 {
+			t.Errorf("expected cpu max usage 100, got %v", maxUsage)
+		}
+	}
+}
+
+func TestEvictWithCheckpointPolicy(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	kubeCli := sc.kubeClient
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+
+	checkpointPG := util.BuildPodGroup("pg1", "c1", "default", 1, nil, "Running")
+	checkpointPG.Annotations = map[string]string{api.CheckpointPolicyAnnotation: "signal"}
+	sc.AddPodGroupV1beta1(checkpointPG)
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "default", 1, nil, "Running"))
+
+	var tasks []*api.TaskInfo
+	for _, pg := range []string{"pg1", "pg2"} {
+		pod := util.BuildPod("c1", "p-"+pg, "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), pg, make(map[string]string), make(map[string]string))
+		kubeCli.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		sc.AddPod(pod)
+		tasks = append(tasks, api.NewTaskInfo(pod))
+	}
+
+	for _, task := range tasks {
+		if err := sc.Evict(task, "preempt"); err != nil {
+			t.Fatalf("failed to evict task %s: %v", task.Name, err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	pod, err := kubeCli.CoreV1().Pods("c1").Get(context.TODO(), "p-pg1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected pod of job with checkpoint policy not to be deleted, got error %v", err)
+	}
+	if len(pod.Annotations[api.CheckpointRequestedAnnotation]) == 0 {
+		t.Errorf("expected checkpoint of pod p-pg1 to be requested, got annotations %v", pod.Annotations)
+	}
+	if _, err := kubeCli.CoreV1().Pods("c1").Get(context.TODO(), "p-pg2", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected pod of job without checkpoint policy to be deleted")
+	}
+	if status := api.NewTaskInfo(pod).Status; status != api.Releasing {
+		t.Errorf("expected pod waiting for checkpoint to be releasing, got %v", status)
+	}
+}
+
+func TestUpdateQueueStatusActiveCapacityWindow(t *testing.T) {
+	queuesResource := vcv1beta1.SchemeGroupVersion.WithResource("queues")
+	vcClient := fakevcclient.NewSimpleClientset(util.BuildQueue("q1", 1, nil))
+	// The metadata of the queues is not updated with their status, as by the API server.
+	vcClient.PrependReactor("update", "queues", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateAction)
+		if update.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		stored, err := vcClient.Tracker().Get(queuesResource, "", "q1")
+		if err != nil {
+			return true, nil, err
+		}
+		queue := update.GetObject().(*vcv1beta1.Queue).DeepCopy()
+		queue.ObjectMeta = stored.(*vcv1beta1.Queue).ObjectMeta
+		return true, queue, vcClient.Tracker().Update(queuesResource, queue, "")
+	})
+	su := &defaultStatusUpdater{vcclient: vcClient}
+
+	for _, window := range []string{"nights", ""} {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1"}}
+		if window != "" {
+			queue.Annotations = map[string]string{api.ActiveCapacityWindowAnnotation: window}
+		}
+		if err := su.UpdateQueueStatus(api.NewQueueInfo(queue)); err != nil {
+			t.Fatalf("failed to update queue status: %v", err)
+		}
+		updated, err := vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get queue: %v", err)
+		}
+		if got := updated.Annotations[api.ActiveCapacityWindowAnnotation]; got != window {
+			t.Errorf("expected active capacity window %q, got %q", window, got)
+		}
+	}
+
+	// The status is updated even if the scheduler is not allowed to patch the queue.
+	vcClient.PrependReactor("patch", "queues", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(queuesResource.GroupResource(), "q1", fmt.Errorf("patch is not allowed"))
+	})
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: map[string]string{api.ActiveCapacityWindowAnnotation: "nights"}},
+		Status:     scheduling.QueueStatus{Running: 1},
+	}
+	if err := su.UpdateQueueStatus(api.NewQueueInfo(queue)); err != nil {
+		t.Errorf("expected the failure to patch the active capacity window not to fail the status update, got %v", err)
+	}
+	updated, err := vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get queue: %v", err)
+	}
+	if updated.Status.Running != 1 {
+		t.Errorf("expected the status of the queue to be updated, got %v", updated.Status)
+	}
+}
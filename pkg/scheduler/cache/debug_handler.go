@@ -0,0 +1,226 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// DebugPathPrefix is the path the debug handler is served under.
+const DebugPathPrefix = "/debug/scheduler/"
+
+// SessionHistory is a ring buffer holding the info of the last sessions.
+type SessionHistory struct {
+	mutex    sync.Mutex
+	sessions []*api.SessionInfo
+	next     int
+	full     bool
+}
+
+// NewSessionHistory returns a SessionHistory keeping the last size sessions.
+func NewSessionHistory(size int) *SessionHistory {
+	if size <= 0 {
+		size = 1
+	}
+	return &SessionHistory{
+		sessions: make([]*api.SessionInfo, size),
+	}
+}
+
+// Add records the info of a closed session, overwriting the oldest one if the history is full.
+func (h *SessionHistory) Add(info *api.SessionInfo) {
+	if info == nil {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.sessions[h.next] = info
+	h.next = (h.next + 1) % len(h.sessions)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// List returns the recorded sessions, the latest one first.
+func (h *SessionHistory) List() []*api.SessionInfo {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.sessions)
+	}
+	sessions := make([]*api.SessionInfo, 0, count)
+	for i := 1; i <= count; i++ {
+		sessions = append(sessions, h.sessions[(h.next-i+len(h.sessions))%len(h.sessions)])
+	}
+	return sessions
+}
+
+// DebugHandler returns a handler serving the nodes, jobs and queues in the
+// scheduler cache and the decisions of the last sessions:
+//
+//	/debug/scheduler/nodes?name=<node>
+//	/debug/scheduler/jobs?namespace=<namespace>&name=<podgroup>
+//	/debug/scheduler/queues?name=<queue>
+//	/debug/scheduler/sessions?limit=<n>
+//
+// Nodes and jobs are printed as in the USR2 dump with format=text. Requests
+// must carry the token as bearer token in the Authorization header.
+func (d *Dumper) DebugHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DebugPathPrefix+"nodes", d.serveNodes)
+	mux.HandleFunc(DebugPathPrefix+"jobs", d.serveJobs)
+	mux.HandleFunc(DebugPathPrefix+"queues", d.serveQueues)
+	mux.HandleFunc(DebugPathPrefix+"sessions", d.serveSessions)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func authorized(r *http.Request, token string) bool {
+	if len(token) == 0 {
+		return false
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	given := strings.TrimPrefix(auth, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+func (d *Dumper) serveNodes(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	snapshot := d.Cache.Snapshot()
+
+	nodes := make([]*api.NodeInfo, 0, len(snapshot.Nodes))
+	for _, node := range snapshot.Nodes {
+		if len(name) == 0 || node.Name == name {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	if r.URL.Query().Get("format") == "text" {
+		var data strings.Builder
+		for _, node := range nodes {
+			data.WriteString(d.printNodeInfo(node))
+		}
+		writeText(w, data.String())
+		return
+	}
+	writeJSON(w, nodes)
+}
+
+func (d *Dumper) serveJobs(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	snapshot := d.Cache.Snapshot()
+
+	jobs := make([]*api.JobInfo, 0, len(snapshot.Jobs))
+	for _, job := range snapshot.Jobs {
+		if len(namespace) != 0 && job.Namespace != namespace {
+			continue
+		}
+		if len(name) != 0 && job.Name != name {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].UID < jobs[j].UID })
+
+	if r.URL.Query().Get("format") == "text" {
+		var data strings.Builder
+		for _, job := range jobs {
+			data.WriteString(d.printJobInfo(job))
+		}
+		writeText(w, data.String())
+		return
+	}
+	writeJSON(w, jobs)
+}
+
+func (d *Dumper) serveQueues(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	snapshot := d.Cache.Snapshot()
+
+	queues := make([]*api.QueueInfo, 0, len(snapshot.Queues))
+	for _, queue := range snapshot.Queues {
+		if len(name) == 0 || queue.Name == name {
+			queues = append(queues, queue)
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool { return queues[i].Name < queues[j].Name })
+	writeJSON(w, queues)
+}
+
+func (d *Dumper) serveSessions(w http.ResponseWriter, r *http.Request) {
+	if d.Sessions == nil {
+		writeJSON(w, []*api.SessionInfo{})
+		return
+	}
+
+	sessions := d.Sessions.List()
+	if limit := r.URL.Query().Get("limit"); len(limit) != 0 {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit "+limit, http.StatusBadRequest)
+			return
+		}
+		if n < len(sessions) {
+			sessions = sessions[:n]
+		}
+	}
+	writeJSON(w, sessions)
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		klog.Errorf("Failed to write debug response: %v", err)
+	}
+}
+
+func writeText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write([]byte(text)); err != nil {
+		klog.Errorf("Failed to write debug response: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSessionHistory(t *testing.T) {
+	history := NewSessionHistory(2)
+	if got := history.List(); len(got) != 0 {
+		t.Errorf("expected empty history, got %v", got)
+	}
+
+	for _, uid := range []string{"s1", "s2", "s3"} {
+		history.Add(&api.SessionInfo{UID: types.UID(uid)})
+	}
+
+	var uids []types.UID
+	for _, session := range history.List() {
+		uids = append(uids, session.UID)
+	}
+	expected := []types.UID{"s3", "s2"}
+	if !reflect.DeepEqual(expected, uids) {
+		t.Errorf("expected sessions %v, got %v", expected, uids)
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns2", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddPod(util.BuildPod("ns2", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)))
+
+	dumper := &Dumper{Cache: sc, Sessions: NewSessionHistory(5)}
+	dumper.Sessions.Add(&api.SessionInfo{
+		UID: "s1",
+		Decisions: []api.SessionDecision{
+			{Action: "allocate", Type: api.BindDecision, Job: "ns1/pg1", Task: "ns1/p1", Node: "n1"},
+		},
+	})
+	server := httptest.NewServer(dumper.DebugHandler("secret"))
+	defer server.Close()
+
+	get := func(path, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if len(token) != 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		statusCode int
+		count      int
+	}{
+		{
+			name:       "no token",
+			path:       DebugPathPrefix + "nodes",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong token",
+			path:       DebugPathPrefix + "nodes",
+			token:      "guess",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "all nodes",
+			path:       DebugPathPrefix + "nodes",
+			token:      "secret",
+			statusCode: http.StatusOK,
+			count:      2,
+		},
+		{
+			name:       "node by name",
+			path:       DebugPathPrefix + "nodes?name=n2",
+			token:      "secret",
+			statusCode: http.StatusOK,
+			count:      1,
+		},
+		{
+			name:       "jobs by namespace",
+			path:       DebugPathPrefix + "jobs?namespace=ns1",
+			token:      "secret",
+			statusCode: http.StatusOK,
+			count:      1,
+		},
+		{
+			name:       "queues",
+			path:       DebugPathPrefix + "queues",
+			token:      "secret",
+			statusCode: http.StatusOK,
+			count:      1,
+		},
+		{
+			name:       "sessions",
+			path:       DebugPathPrefix + "sessions?limit=3",
+			token:      "secret",
+			statusCode: http.StatusOK,
+			count:      1,
+		},
+		{
+			name:       "invalid limit",
+			path:       DebugPathPrefix + "sessions?limit=x",
+			token:      "secret",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := get(test.path, test.token)
+			defer resp.Body.Close()
+			if resp.StatusCode != test.statusCode {
+				t.Fatalf("expected status %d, got %d", test.statusCode, resp.StatusCode)
+			}
+			if test.statusCode != http.StatusOK {
+				return
+			}
+			var items []json.RawMessage
+			if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(items) != test.count {
+				t.Errorf("expected %d items, got %d", test.count, len(items))
+			}
+		})
+	}
+
+	resp := get(DebugPathPrefix+"jobs?namespace=ns2&format=text", "secret")
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if !strings.Contains(string(body), "name pg2") || strings.Contains(string(body), "name pg1") {
+		t.Errorf("expected text dump of job pg2 only, got %s", body)
+	}
+}
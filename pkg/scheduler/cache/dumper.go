@@ -17,7 +17,6 @@
 package cache
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -36,11 +35,13 @@ import (
 // for debugging purposes. Usage: run `kill -s USR2 <pid>` in the shell, where <pid>
 // is the process id of the scheduler process.
 type Dumper struct {
-	Cache   Cache
-	RootDir string // target directory for the dumped json file
+	Cache    Cache
+	RootDir  string          // target directory for the dumped json file
+	Sessions *SessionHistory // decisions of the last sessions, served by DebugHandler
 }
 
-// dumpToJSONFile marsh scheduler cache snapshot to json file
+// dumpToJSONFile marsh scheduler cache snapshot to json file, the file can be
+// loaded again by LoadSnapshotFile
 func (d *Dumper) dumpToJSONFile() {
 	snapshot := d.Cache.Snapshot()
 	name := fmt.Sprintf("snapshot-%d.json", time.Now().Unix())
@@ -52,7 +53,7 @@ func (d *Dumper) dumpToJSONFile() {
 	}
 	defer file.Close()
 	klog.Infoln("Starting to dump info in scheduler cache to file", fName)
-	if err = json.NewEncoder(file).Encode(snapshot.Nodes); err != nil {
+	if err = EncodeSnapshot(file, snapshot); err != nil {
 		klog.Errorf("Failed to dump info in scheduler cache, json encode error: %v", err)
 		return
 	}
@@ -0,0 +1,204 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// SnapshotVersion is the version of the snapshot format written by the Dumper.
+const SnapshotVersion = "v1"
+
+// SnapshotDump is the serialized form of an api.ClusterInfo. Revocable nodes are
+// stored by name as they share the NodeInfo of Nodes.
+type SnapshotDump struct {
+	Version        string                                   `json:"version"`
+	Timestamp      metav1.Time                              `json:"timestamp"`
+	Nodes          map[string]*api.NodeInfo                 `json:"nodes"`
+	Jobs           map[api.JobID]*api.JobInfo               `json:"jobs"`
+	Queues         map[api.QueueID]*api.QueueInfo           `json:"queues"`
+	NamespaceInfo  map[api.NamespaceName]*api.NamespaceInfo `json:"namespaces"`
+	RevocableNodes []string                                 `json:"revocableNodes"`
+	NodeList       []string                                 `json:"nodeList"`
+	CSINodesStatus map[string]*api.CSINodeStatusInfo        `json:"csiNodesStatus"`
+}
+
+// NewSnapshotDump returns the serializable form of snapshot.
+func NewSnapshotDump(snapshot *api.ClusterInfo) *SnapshotDump {
+	dump := &SnapshotDump{
+		Version:        SnapshotVersion,
+		Timestamp:      metav1.NewTime(time.Now()),
+		Nodes:          snapshot.Nodes,
+		Jobs:           snapshot.Jobs,
+		Queues:         snapshot.Queues,
+		NamespaceInfo:  snapshot.NamespaceInfo,
+		NodeList:       snapshot.NodeList,
+		CSINodesStatus: snapshot.CSINodesStatus,
+	}
+	for name := range snapshot.RevocableNodes {
+		dump.RevocableNodes = append(dump.RevocableNodes, name)
+	}
+	sort.Strings(dump.RevocableNodes)
+	return dump
+}
+
+// EncodeSnapshot writes snapshot to w in the current snapshot format.
+func EncodeSnapshot(w io.Writer, snapshot *api.ClusterInfo) error {
+	return json.NewEncoder(w).Encode(NewSnapshotDump(snapshot))
+}
+
+// LoadSnapshot reads a snapshot written by EncodeSnapshot and rebuilds the
+// api.ClusterInfo it was taken from. Dumps written before the format was
+// versioned only contain nodes, they are loaded as a snapshot without jobs.
+func LoadSnapshot(r io.Reader) (*api.ClusterInfo, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+
+	dump := &SnapshotDump{}
+	if _, found := fields["version"]; !found {
+		klog.V(3).Infof("Snapshot has no version, loading it as a nodes only dump")
+		if err := json.Unmarshal(data, &dump.Nodes); err != nil {
+			return nil, fmt.Errorf("failed to decode nodes of snapshot: %v", err)
+		}
+		for name := range dump.Nodes {
+			dump.NodeList = append(dump.NodeList, name)
+		}
+		sort.Strings(dump.NodeList)
+	} else {
+		if err := json.Unmarshal(data, dump); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot: %v", err)
+		}
+		if dump.Version != SnapshotVersion {
+			return nil, fmt.Errorf("unsupported snapshot version %q, expected %q", dump.Version, SnapshotVersion)
+		}
+	}
+
+	return dump.clusterInfo()
+}
+
+// LoadSnapshotFile loads a snapshot from the json file at path.
+func LoadSnapshotFile(path string) (*api.ClusterInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot %s: %v", path, err)
+	}
+	defer file.Close()
+
+	snapshot, err := LoadSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot %s: %v", path, err)
+	}
+	return snapshot, nil
+}
+
+// clusterInfo rebuilds the derived state of the decoded objects, e.g. the task
+// status index of jobs and the idle/used resources of nodes, the same way the
+// scheduler cache does when it takes a snapshot.
+func (d *SnapshotDump) clusterInfo() (*api.ClusterInfo, error) {
+	snapshot := &api.ClusterInfo{
+		Nodes:          make(map[string]*api.NodeInfo, len(d.Nodes)),
+		Jobs:           make(map[api.JobID]*api.JobInfo, len(d.Jobs)),
+		Queues:         make(map[api.QueueID]*api.QueueInfo, len(d.Queues)),
+		NamespaceInfo:  make(map[api.NamespaceName]*api.NamespaceInfo, len(d.NamespaceInfo)),
+		RevocableNodes: make(map[string]*api.NodeInfo, len(d.RevocableNodes)),
+		NodeList:       append([]string{}, d.NodeList...),
+		CSINodesStatus: make(map[string]*api.CSINodeStatusInfo, len(d.CSINodesStatus)),
+	}
+
+	for name, node := range d.Nodes {
+		if node == nil || node.Node == nil {
+			return nil, fmt.Errorf("node <%s> has no node object", name)
+		}
+		info := api.NewNodeInfo(node.Node)
+		for _, task := range node.Tasks {
+			if err := info.AddTask(task); err != nil {
+				return nil, fmt.Errorf("failed to add task <%s/%s> to node <%s>: %v",
+					task.Namespace, task.Name, name, err)
+			}
+		}
+		// Numa information already accounts for the tasks on the node.
+		info.NumaInfo = node.NumaInfo
+		info.NumaChgFlag = node.NumaChgFlag
+		info.NumaSchedulerInfo = node.NumaSchedulerInfo
+		if node.ResourceUsage != nil {
+			info.ResourceUsage = node.ResourceUsage
+		}
+		for image, summary := range node.ImageStates {
+			info.ImageStates[image] = summary
+		}
+		snapshot.Nodes[name] = info
+	}
+
+	for _, name := range d.RevocableNodes {
+		node, found := snapshot.Nodes[name]
+		if !found {
+			return nil, fmt.Errorf("revocable node <%s> is not in snapshot nodes", name)
+		}
+		snapshot.RevocableNodes[name] = node
+	}
+
+	for uid, job := range d.Jobs {
+		if job == nil || job.PodGroup == nil {
+			return nil, fmt.Errorf("job <%s> has no podgroup", uid)
+		}
+		if job.Tasks == nil {
+			job.Tasks = map[api.TaskID]*api.TaskInfo{}
+		}
+		if job.TaskMinAvailable == nil {
+			job.TaskMinAvailable = map[api.TaskID]int32{}
+		}
+		if job.Budget == nil {
+			job.Budget = api.NewDisruptionBudget("", "")
+		}
+		// Clone recomputes the status index and resources from the tasks.
+		snapshot.Jobs[uid] = job.Clone()
+	}
+
+	for uid, queue := range d.Queues {
+		if queue == nil || queue.Queue == nil {
+			return nil, fmt.Errorf("queue <%s> has no queue object", uid)
+		}
+		snapshot.Queues[uid] = queue
+	}
+
+	for name, ns := range d.NamespaceInfo {
+		snapshot.NamespaceInfo[name] = ns
+	}
+
+	for name, status := range d.CSINodesStatus {
+		snapshot.CSINodesStatus[name] = status
+	}
+
+	return snapshot, nil
+}
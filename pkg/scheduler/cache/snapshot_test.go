@@ -0,0 +1,138 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning))
+	sc.AddPod(util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddResourceQuota(util.BuildResourceQuota("rq1", "ns1", api.BuildResourceList("8", "16Gi")))
+
+	expected := sc.Snapshot()
+
+	buf := &bytes.Buffer{}
+	if err := EncodeSnapshot(buf, expected); err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	snapshot, err := LoadSnapshot(buf)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+
+	job, found := snapshot.Jobs["ns1/pg1"]
+	if !found {
+		t.Fatalf("expected job ns1/pg1 in snapshot, got %v", snapshot.Jobs)
+	}
+	if len(job.Tasks) != 2 {
+		t.Errorf("expected 2 tasks in job, got %d", len(job.Tasks))
+	}
+	if len(job.TaskStatusIndex[api.Running]) != 1 || len(job.TaskStatusIndex[api.Pending]) != 1 {
+		t.Errorf("expected one running and one pending task, got %v", job.TaskStatusIndex)
+	}
+	if job.MinAvailable != 2 || job.Queue != "q1" {
+		t.Errorf("expected minAvailable 2 in queue q1, got %d in %s", job.MinAvailable, job.Queue)
+	}
+
+	node, found := snapshot.Nodes["n1"]
+	if !found {
+		t.Fatalf("expected node n1 in snapshot")
+	}
+	if !node.Idle.Equal(expected.Nodes["n1"].Idle, api.Zero) || !node.Used.Equal(expected.Nodes["n1"].Used, api.Zero) {
+		t.Errorf("expected node idle %v and used %v, got %v and %v",
+			expected.Nodes["n1"].Idle, expected.Nodes["n1"].Used, node.Idle, node.Used)
+	}
+	if len(node.Tasks) != 1 {
+		t.Errorf("expected 1 task on node n1, got %d", len(node.Tasks))
+	}
+
+	if _, found := snapshot.Queues["q1"]; !found {
+		t.Errorf("expected queue q1 in snapshot")
+	}
+	ns, found := snapshot.NamespaceInfo["ns1"]
+	if !found {
+		t.Fatalf("expected namespace ns1 in snapshot")
+	}
+	if _, found := ns.QuotaStatus["rq1"]; !found {
+		t.Errorf("expected quota rq1 in namespace ns1, got %v", ns.QuotaStatus)
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	legacy, err := json.Marshal(sc.Snapshot().Nodes)
+	if err != nil {
+		t.Fatalf("failed to marshal nodes: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		expectErr bool
+		nodes     int
+	}{
+		{
+			name:  "nodes only dump",
+			data:  string(legacy),
+			nodes: 1,
+		},
+		{
+			name:      "unsupported version",
+			data:      `{"version":"v0"}`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid json",
+			data:      `nodes`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := LoadSnapshot(strings.NewReader(test.data))
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(snapshot.Nodes) != test.nodes || len(snapshot.NodeList) != test.nodes {
+				t.Errorf("expected %d nodes, got %d", test.nodes, len(snapshot.Nodes))
+			}
+		})
+	}
+}
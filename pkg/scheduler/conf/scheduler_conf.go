@@ -28,6 +28,17 @@ type SchedulerConfiguration struct {
 	// Configurations is configuration for actions
 	Configurations       []Configuration   `yaml:"configurations"`
 	MetricsConfiguration map[string]string `yaml:"metrics"`
+	// Profiles defines the policies jobs can select by name
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Profile defines a named policy selected by the jobs of a queue or a PodGroup
+type Profile struct {
+	// Name is the name jobs select the profile by
+	Name string `yaml:"name"`
+	// Tiers defines the plugins placing the tasks of the jobs selecting the profile,
+	// they replace Tiers when ordering the tasks of a job and filtering and scoring nodes for them
+	Tiers []Tier `yaml:"tiers"`
 }
 
 // Tier defines plugin tier
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// SchedulerConfigurationResource is the cluster scoped resource holding the
+// scheduler configuration in its spec, with the same fields as the configuration file.
+var SchedulerConfigurationResource = schema.GroupVersionResource{
+	Group:    "scheduling.volcano.sh",
+	Version:  "v1beta1",
+	Resource: "schedulerconfigurations",
+}
+
+const (
+	// SchedulerConfigurationValid is the condition type telling whether the
+	// spec of the observed generation was accepted by the scheduler.
+	SchedulerConfigurationValid = "Valid"
+
+	schedulerConfigurationAccepted = "Accepted"
+	schedulerConfigurationInvalid  = "Invalid"
+
+	// schedulerConfResourceResync is the period the resource is synced again at,
+	// so that a generation whose status failed to be updated is handled again.
+	schedulerConfResourceResync = time.Minute
+)
+
+// schedulerConfFromResource returns the scheduler configuration in the spec of the resource.
+func schedulerConfFromResource(resource *unstructured.Unstructured) (string, error) {
+	spec, found, err := unstructured.NestedMap(resource.Object, "spec")
+	if err != nil {
+		return "", fmt.Errorf("invalid spec: %v", err)
+	}
+	if !found {
+		return "", fmt.Errorf("spec is missing")
+	}
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// setSchedulerConfResourceStatus records the result of loading the observed generation of the resource in its status.
+func setSchedulerConfResourceStatus(resource *unstructured.Unstructured, loadErr error) error {
+	status, _, err := unstructured.NestedMap(resource.Object, "status")
+	if err != nil {
+		return fmt.Errorf("invalid status: %v", err)
+	}
+
+	var conditions []metav1.Condition
+	rawConditions, _, _ := unstructured.NestedSlice(status, "conditions")
+	for _, raw := range rawConditions {
+		rawCondition, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawCondition, &condition); err != nil {
+			// The conditions are owned by the scheduler, drop the ones it cannot read.
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+
+	condition := metav1.Condition{
+		Type:               SchedulerConfigurationValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: resource.GetGeneration(),
+		Reason:             schedulerConfigurationAccepted,
+		Message:            "The scheduler configuration is in use",
+	}
+	if loadErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = schedulerConfigurationInvalid
+		condition.Message = fmt.Sprintf("The previous scheduler configuration is kept in use: %v", loadErr)
+	}
+	meta.SetStatusCondition(&conditions, condition)
+
+	rawConditions = make([]interface{}, 0, len(conditions))
+	for i := range conditions {
+		rawCondition, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&conditions[i])
+		if err != nil {
+			return err
+		}
+		rawConditions = append(rawConditions, rawCondition)
+	}
+	if status == nil {
+		status = map[string]interface{}{}
+	}
+	status["observedGeneration"] = resource.GetGeneration()
+	status["conditions"] = rawConditions
+	return unstructured.SetNestedMap(resource.Object, status, "status")
+}
+
+// watchSchedulerConfResource applies the scheduler configuration resource
+// whenever its spec changes. The current spec is applied before it returns.
+func (pc *Scheduler) watchSchedulerConfResource(stopCh <-chan struct{}) {
+	resource, err := pc.dynamicClient.Resource(SchedulerConfigurationResource).Get(context.TODO(), pc.schedulerConfResource, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("Failed to get scheduler configuration resource %s, using default configuration: %v", pc.schedulerConfResource, err)
+	} else {
+		pc.syncSchedulerConfResource(resource)
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(pc.dynamicClient, schedulerConfResourceResync, metav1.NamespaceAll,
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", pc.schedulerConfResource).String()
+		})
+	informer := factory.ForResource(SchedulerConfigurationResource).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: pc.syncSchedulerConfResource,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			pc.syncSchedulerConfResource(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			klog.Warningf("Scheduler configuration resource %s is deleted, using previous configuration", pc.schedulerConfResource)
+		},
+	})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+}
+
+// syncSchedulerConfResource loads the spec of the resource if its generation
+// was not handled yet and reports the result in the status of the resource.
+// The generation is only handled once its status is updated, it is synced
+// again at the next event or resync otherwise.
+// It is called sequentially, so schedulerConfGeneration needs no lock.
+func (pc *Scheduler) syncSchedulerConfResource(obj interface{}) {
+	resource, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		klog.Errorf("Failed to convert %v to *unstructured.Unstructured", obj)
+		return
+	}
+	if resource.GetGeneration() == pc.schedulerConfGeneration {
+		// Only the status was updated.
+		return
+	}
+
+	err := pc.loadSchedulerConfResource(resource)
+	if err != nil {
+		klog.Errorf("Scheduler configuration resource %s generation %d is invalid, using previous configuration: %v",
+			resource.GetName(), resource.GetGeneration(), err)
+	}
+
+	resource = resource.DeepCopy()
+	if err := setSchedulerConfResourceStatus(resource, err); err != nil {
+		klog.Errorf("Failed to set status of scheduler configuration resource %s: %v", resource.GetName(), err)
+		return
+	}
+	if _, err := pc.dynamicClient.Resource(SchedulerConfigurationResource).UpdateStatus(context.TODO(), resource, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update status of scheduler configuration resource %s: %v", resource.GetName(), err)
+		return
+	}
+	pc.schedulerConfGeneration = resource.GetGeneration()
+}
+
+// loadSchedulerConfResource validates the spec of the resource and puts it in use.
+func (pc *Scheduler) loadSchedulerConfResource(resource *unstructured.Unstructured) error {
+	config, err := schedulerConfFromResource(resource)
+	if err != nil {
+		return err
+	}
+	if errs := ValidateSchedulerConf(config); len(errs) != 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	actions, plugins, profiles, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
+	if err != nil {
+		return err
+	}
+
+	pc.mutex.Lock()
+	pc.actions = actions
+	pc.plugins = plugins
+	pc.profiles = profiles
+	pc.configurations = configurations
+	pc.metricsConf = metricsConf
+	pc.mutex.Unlock()
+	pc.cache.SetMetricsConf(metricsConf)
+
+	actionNames, pluginNames := pc.getSchedulerConf()
+	klog.V(2).Infof("Successfully loaded scheduler configuration resource %s generation %d, actions: %v, plugins: %v",
+		resource.GetName(), resource.GetGeneration(), actionNames, pluginNames)
+	return nil
+}
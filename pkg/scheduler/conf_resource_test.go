@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSchedulerConfFromResource(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"actions": "enqueue, allocate",
+			"tiers": []interface{}{
+				map[string]interface{}{
+					"plugins": []interface{}{
+						map[string]interface{}{"name": "gang"},
+						map[string]interface{}{
+							"name":      "nodeorder",
+							"arguments": map[string]interface{}{"leastrequested.weight": int64(2)},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	config, err := schedulerConfFromResource(resource)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := ValidateSchedulerConf(config); len(errs) != 0 {
+		t.Errorf("expected configuration %q to be valid, got %v", config, errs)
+	}
+
+	if _, err := schedulerConfFromResource(&unstructured.Unstructured{Object: map[string]interface{}{}}); err == nil {
+		t.Errorf("expected error for resource without spec")
+	}
+}
+
+func TestSetSchedulerConfResourceStatus(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	resource.SetGeneration(1)
+	if err := setSchedulerConfResourceStatus(resource, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSchedulerConfResourceStatus(t, resource, metav1.ConditionTrue, schedulerConfigurationAccepted)
+
+	resource.SetGeneration(2)
+	if err := setSchedulerConfResourceStatus(resource, fmt.Errorf("unknown action \"foo\"")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSchedulerConfResourceStatus(t, resource, metav1.ConditionFalse, schedulerConfigurationInvalid)
+}
+
+func TestSyncSchedulerConfResourceStatusFailure(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "scheduling.volcano.sh/v1beta1",
+		"kind":       "SchedulerConfiguration",
+		"spec":       map[string]interface{}{"actions": "foo"},
+	}}
+	resource.SetName("volcano")
+	resource.SetGeneration(1)
+
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{SchedulerConfigurationResource: "SchedulerConfigurationList"}, resource)
+	failures := 1
+	client.PrependReactor("update", SchedulerConfigurationResource.Resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" || failures == 0 {
+			return false, nil, nil
+		}
+		failures--
+		return true, nil, fmt.Errorf("conflict")
+	})
+	pc := &Scheduler{schedulerConfResource: "volcano", dynamicClient: client}
+
+	pc.syncSchedulerConfResource(resource)
+	if pc.schedulerConfGeneration != 0 {
+		t.Errorf("expected generation not to be handled as its status failed to be updated, got %d", pc.schedulerConfGeneration)
+	}
+
+	pc.syncSchedulerConfResource(resource)
+	if pc.schedulerConfGeneration != 1 {
+		t.Errorf("expected generation 1 to be handled, got %d", pc.schedulerConfGeneration)
+	}
+	updated, err := client.Resource(SchedulerConfigurationResource).Get(context.TODO(), "volcano", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSchedulerConfResourceStatus(t, updated, metav1.ConditionFalse, schedulerConfigurationInvalid)
+}
+
+func checkSchedulerConfResourceStatus(t *testing.T, resource *unstructured.Unstructured, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+
+	observed, _, _ := unstructured.NestedInt64(resource.Object, "status", "observedGeneration")
+	if observed != resource.GetGeneration() {
+		t.Errorf("expected observed generation %d, got %d", resource.GetGeneration(), observed)
+	}
+
+	rawConditions, _, _ := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if len(rawConditions) != 1 {
+		t.Fatalf("expected 1 condition, got %v", rawConditions)
+	}
+	var conditions []metav1.Condition
+	for _, raw := range rawConditions {
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw.(map[string]interface{}), &condition); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	condition := meta.FindStatusCondition(conditions, SchedulerConfigurationValid)
+	if condition == nil {
+		t.Fatalf("expected condition %s, got %v", SchedulerConfigurationValid, conditions)
+	}
+	if condition.Status != status || condition.Reason != reason || condition.ObservedGeneration != resource.GetGeneration() {
+		t.Errorf("expected condition with status %s, reason %s and generation %d, got %v",
+			status, reason, resource.GetGeneration(), condition)
+	}
+}
@@ -17,6 +17,11 @@ limitations under the License.
 package framework
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/conf"
@@ -89,6 +94,135 @@ func (a Arguments) GetBool(ptr *bool, key string) {
 	*ptr = value
 }
 
+// ArgumentType is the type of the value of a plugin argument.
+type ArgumentType int
+
+const (
+	// AnyArgument accepts any value
+	AnyArgument ArgumentType = iota
+	// IntArgument accepts integers
+	IntArgument
+	// FloatArgument accepts floats and integers
+	FloatArgument
+	// BoolArgument accepts booleans
+	BoolArgument
+	// StringArgument accepts strings
+	StringArgument
+	// DurationArgument accepts strings parsed by time.ParseDuration
+	DurationArgument
+	// MapArgument accepts maps
+	MapArgument
+	// ListArgument accepts lists
+	ListArgument
+)
+
+func (t ArgumentType) String() string {
+	switch t {
+	case IntArgument:
+		return "int"
+	case FloatArgument:
+		return "float"
+	case BoolArgument:
+		return "bool"
+	case StringArgument:
+		return "string"
+	case DurationArgument:
+		return "duration"
+	case MapArgument:
+		return "map"
+	case ListArgument:
+		return "list"
+	default:
+		return "any"
+	}
+}
+
+// ArgumentSchema declares the arguments a plugin accepts and their types.
+// A key ending with "*" matches all keys with that prefix.
+type ArgumentSchema map[string]ArgumentType
+
+// Validate checks that every argument is declared in the schema and has the declared type.
+func (s ArgumentSchema) Validate(args Arguments) []error {
+	var errs []error
+
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		argType, found := s.lookup(key)
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown argument %q", key))
+			continue
+		}
+		if err := checkArgumentType(args[key], argType); err != nil {
+			errs = append(errs, fmt.Errorf("argument %q: %v", key, err))
+		}
+	}
+
+	return errs
+}
+
+func (s ArgumentSchema) lookup(key string) (ArgumentType, bool) {
+	if argType, found := s[key]; found {
+		return argType, true
+	}
+	// The longest prefix wins, e.g. for "a.b.*" and "a.*".
+	matched := ""
+	for pattern := range s {
+		prefix := strings.TrimSuffix(pattern, "*")
+		if prefix != pattern && strings.HasPrefix(key, prefix) && len(prefix) > len(matched) {
+			matched = prefix
+		}
+	}
+	if len(matched) == 0 {
+		return AnyArgument, false
+	}
+	return s[matched+"*"], true
+}
+
+func checkArgumentType(value interface{}, argType ArgumentType) error {
+	valid := true
+	switch argType {
+	case IntArgument:
+		_, valid = value.(int)
+	case FloatArgument:
+		switch value.(type) {
+		case int, float64:
+		default:
+			valid = false
+		}
+	case BoolArgument:
+		_, valid = value.(bool)
+	case StringArgument:
+		_, valid = value.(string)
+	case DurationArgument:
+		str, ok := value.(string)
+		if !ok {
+			valid = false
+			break
+		}
+		if _, err := time.ParseDuration(str); err != nil {
+			return fmt.Errorf("invalid duration %q: %v", str, err)
+		}
+	case MapArgument:
+		switch value.(type) {
+		case map[interface{}]interface{}, map[string]interface{}:
+		default:
+			valid = false
+		}
+	case ListArgument:
+		_, valid = value.([]interface{})
+	}
+
+	if !valid {
+		return fmt.Errorf("expected %v, got %T", argType, value)
+	}
+	return nil
+}
+
 // GetArgOfActionFromConf return argument of action reading from configuration of schedule
 func GetArgOfActionFromConf(configurations []conf.Configuration, actionName string) Arguments {
 	for _, c := range configurations {
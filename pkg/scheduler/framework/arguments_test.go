@@ -187,3 +187,56 @@ func TestGetArgOfActionFromConf(t *testing.T) {
 		}
 	}
 }
+
+func TestArgumentSchemaValidate(t *testing.T) {
+	schema := ArgumentSchema{
+		"weight":       IntArgument,
+		"factor":       FloatArgument,
+		"enable":       BoolArgument,
+		"period":       DurationArgument,
+		"thresholds":   MapArgument,
+		"resources.*":  IntArgument,
+		"resources.a*": StringArgument,
+	}
+
+	cases := []struct {
+		name      string
+		arguments Arguments
+		errs      int
+	}{
+		{
+			name: "valid arguments",
+			arguments: Arguments{
+				"weight":       1,
+				"factor":       1,
+				"enable":       true,
+				"period":       "5m",
+				"thresholds":   map[interface{}]interface{}{"cpu": 80},
+				"resources.b":  2,
+				"resources.ab": "x",
+			},
+		},
+		{
+			name:      "unknown argument",
+			arguments: Arguments{"weigth": 1},
+			errs:      1,
+		},
+		{
+			name: "wrong types",
+			arguments: Arguments{
+				"weight":      "1",
+				"factor":      "1.5",
+				"enable":      "true",
+				"period":      "5 minutes",
+				"resources.b": 1.5,
+			},
+			errs: 5,
+		},
+	}
+
+	for _, c := range cases {
+		if errs := schema.Validate(c.arguments); len(errs) != c.errs {
+			t.Errorf("case %s: expected %d errors, got %v", c.name, c.errs, errs)
+		}
+	}
+}
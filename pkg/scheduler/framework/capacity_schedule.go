@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// CapacityWindowChanged is the reason of the event recorded on a queue when its active capacity window changed
+const CapacityWindowChanged = "CapacityWindowChanged"
+
+// The active capacity windows of the queues are kept across sessions to record
+// an event on the queues only when their active window changed. The active window
+// is persisted in the annotations of the queues when their status is updated.
+var (
+	capacityWindowMutex   sync.Mutex
+	activeCapacityWindows = map[api.QueueID]string{}
+)
+
+// ApplyQueueCapacitySchedules replaces the queues of the session having an active capacity window
+// by copies with the capacity of the window. It is called by the plugins computing the capacity of
+// the queues, the queues are only replaced once per session.
+func (ssn *Session) ApplyQueueCapacitySchedules() {
+	if ssn.capacitySchedulesApplied {
+		return
+	}
+	ssn.capacitySchedulesApplied = true
+	ssn.capacityWindows = map[api.QueueID]string{}
+
+	now := time.Now()
+	for id, queue := range ssn.Queues {
+		if len(queue.CapacitySchedule) == 0 {
+			ssn.recordActiveCapacityWindow(queue, nil)
+			continue
+		}
+		window := queue.ActiveCapacityWindow(now)
+		if window != nil {
+			klog.V(4).Infof("Capacity window <%s> of queue <%s> is active.", window.Name, queue.Name)
+			ssn.Queues[id] = queue.WithCapacityWindow(window)
+			ssn.capacityWindows[id] = window.Name
+		}
+		ssn.recordActiveCapacityWindow(queue, window)
+	}
+}
+
+func (ssn *Session) recordActiveCapacityWindow(queue *api.QueueInfo, window *api.CapacityWindow) {
+	name := ""
+	if window != nil {
+		name = window.Name
+	}
+
+	capacityWindowMutex.Lock()
+	previous := activeCapacityWindows[queue.UID]
+	if name == "" {
+		delete(activeCapacityWindows, queue.UID)
+	} else {
+		activeCapacityWindows[queue.UID] = name
+	}
+	capacityWindowMutex.Unlock()
+
+	if previous == name {
+		return
+	}
+	msg := "No capacity window is active, the capacity of the queue spec applies"
+	if name != "" {
+		msg = fmt.Sprintf("Capacity window %s is active", name)
+	}
+	ssn.RecordQueueEvent(queue, v1.EventTypeNormal, CapacityWindowChanged, msg)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// queueStatusRecorder records the queues whose status is updated.
+type queueStatusRecorder struct {
+	util.FakeStatusUpdater
+	queues map[string]*api.QueueInfo
+}
+
+func (r *queueStatusRecorder) UpdateQueueStatus(queue *api.QueueInfo) error {
+	r.queues[queue.Name] = queue
+	return nil
+}
+
+func TestActiveCapacityWindowAnnotation(t *testing.T) {
+	recorder := &queueStatusRecorder{queues: map[string]*api.QueueInfo{}}
+	sc := cache.NewCustomMockSchedulerCache("volcano", nil, nil, recorder, nil, nil, nil)
+
+	now := time.Now()
+	schedule := fmt.Sprintf(`[{"name": "now", "window": "%s-%s", "weight": 2}]`,
+		now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04"))
+	sc.AddQueueV1beta1(util.BuildQueueWithAnnos("q1", 1, nil, map[string]string{api.CapacityScheduleAnnotation: schedule}))
+	sc.AddQueueV1beta1(util.BuildQueueWithAnnos("q2", 1, nil, map[string]string{api.ActiveCapacityWindowAnnotation: "expired"}))
+
+	ssn := OpenSession(sc, nil, nil)
+	defer CloseSession(ssn)
+	ssn.ApplyQueueCapacitySchedules()
+	updateQueueStatus(ssn)
+
+	if q1, found := recorder.queues["q1"]; !found || q1.Queue.Annotations[api.ActiveCapacityWindowAnnotation] != "now" {
+		t.Errorf("expected the active capacity window of q1 to be now, got %v", recorder.queues["q1"])
+	}
+	q2, found := recorder.queues["q2"]
+	if !found {
+		t.Fatalf("expected the status of q2 to be updated")
+	}
+	if window, found := q2.Queue.Annotations[api.ActiveCapacityWindowAnnotation]; found {
+		t.Errorf("expected the active capacity window of q2 to be cleared, got %q", window)
+	}
+	if sc.Queues["q2"].Queue.Annotations[api.ActiveCapacityWindowAnnotation] != "expired" {
+		t.Errorf("expected the queue of the cache not to be changed")
+	}
+
+	// The queues are not updated again once their annotation is up to date.
+	ssn.Queues["q2"] = q2
+	delete(recorder.queues, "q2")
+	updateQueueStatus(ssn)
+	if _, found := recorder.queues["q2"]; found {
+		t.Errorf("expected the status of q2 not to be updated again")
+	}
+}
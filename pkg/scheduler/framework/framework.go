@@ -24,10 +24,16 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
 )
 
 // OpenSession start the session
 func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration) *Session {
+	return OpenSessionWithProfiles(cache, tiers, nil, configurations)
+}
+
+// OpenSessionWithProfiles starts the session with the profiles the jobs can select
+func OpenSessionWithProfiles(cache cache.Cache, tiers []conf.Tier, profiles []conf.Profile, configurations []conf.Configuration) *Session {
 	ssn := openSession(cache)
 	ssn.Tiers = tiers
 	ssn.Configurations = configurations
@@ -36,27 +42,47 @@ func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Con
 
 	for _, tier := range tiers {
 		for _, plugin := range tier.Plugins {
-			if pb, found := GetPluginBuilder(plugin.Name); !found {
-				klog.Errorf("Failed to get plugin %s.", plugin.Name)
-			} else {
-				plugin := pb(plugin.Arguments)
-				ssn.plugins[plugin.Name()] = plugin
-				onSessionOpenStart := time.Now()
-				plugin.OnSessionOpen(ssn)
-				metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionOpen, metrics.Duration(onSessionOpenStart))
-			}
+			openPlugin(ssn, "", plugin)
 		}
 	}
+	for _, profile := range profiles {
+		ssn.openProfile(profile)
+	}
+	if len(profiles) != 0 {
+		ssn.checkJobProfiles()
+	}
 	return ssn
 }
 
+// openPlugin builds the plugin and opens it for the profile, the tiers of the session if profile is empty
+func openPlugin(ssn *Session, profile string, option conf.PluginOption) {
+	pb, found := GetPluginBuilder(option.Name)
+	if !found {
+		klog.Errorf("Failed to get plugin %s.", option.Name)
+		return
+	}
+	plugin := pb(option.Arguments)
+	ssn.plugins[profileKey(profile, plugin.Name())] = plugin
+	ssn.StartSpan("OnSessionOpen", tracing.PluginKey.String(plugin.Name()), tracing.ProfileKey.String(profile))
+	onSessionOpenStart := time.Now()
+	plugin.OnSessionOpen(ssn)
+	metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionOpen, metrics.Duration(onSessionOpenStart))
+	ssn.EndSpan()
+}
+
 // CloseSession close the session
 func CloseSession(ssn *Session) {
 	for _, plugin := range ssn.plugins {
+		ssn.StartSpan("OnSessionClose", tracing.PluginKey.String(plugin.Name()))
 		onSessionCloseStart := time.Now()
 		plugin.OnSessionClose(ssn)
 		metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionClose, metrics.Duration(onSessionCloseStart))
+		ssn.EndSpan()
 	}
 
 	closeSession(ssn)
+	// End the spans left open, the span of the session last.
+	for len(ssn.spans) != 0 {
+		ssn.EndSpan()
+	}
 }
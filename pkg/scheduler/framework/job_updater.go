@@ -11,6 +11,7 @@ import (
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
 )
 
 const (
@@ -94,6 +95,10 @@ func (ju *jobUpdater) updateJob(index int) {
 
 	job.PodGroup.Status = jobStatus(ssn, job)
 	oldStatus, found := ssn.podGroupStatus[job.UID]
+	if found && job.PodGroup.Status.Phase == scheduling.PodGroupRunning &&
+		(oldStatus.Phase == scheduling.PodGroupPending || oldStatus.Phase == scheduling.PodGroupInqueue) {
+		metrics.UpdateQueueJobWaitTime(string(job.Queue), metrics.Duration(job.CreationTimestamp.Time))
+	}
 	updatePG := !found || isPodGroupStatusUpdated(job.PodGroup.Status, oldStatus)
 	if _, err := ssn.cache.UpdateJobStatus(job, updatePG); err != nil {
 		klog.Errorf("Failed to update job <%s/%s>: %v",
@@ -34,6 +34,12 @@ type PluginBuilder = func(Arguments) Plugin
 // Plugin management
 var pluginBuilders = map[string]PluginBuilder{}
 
+// Argument schemas of the plugins, plugins without schema are not validated
+var pluginArgumentSchemas = map[string]ArgumentSchema{}
+
+// Plugins which may be configured in profiles
+var placementPlugins = map[string]bool{}
+
 // RegisterPluginBuilder register the plugin
 func RegisterPluginBuilder(name string, pc PluginBuilder) {
 	pluginMutex.Lock()
@@ -50,6 +56,42 @@ func CleanupPluginBuilders() {
 	pluginBuilders = map[string]PluginBuilder{}
 }
 
+// RegisterPluginArgumentSchema declares the arguments accepted by the plugin,
+// it is used to validate scheduler configurations.
+func RegisterPluginArgumentSchema(name string, schema ArgumentSchema) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	pluginArgumentSchemas[name] = schema
+}
+
+// RegisterPlacementPlugin allows the plugin to be configured in profiles. Only the callbacks ordering the
+// tasks and filtering and scoring the nodes are dispatched by profile, the plugin must have no other side
+// effect when it is opened, e.g. updating metrics, as it is opened once more for every profile.
+func RegisterPlacementPlugin(name string) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	placementPlugins[name] = true
+}
+
+// IsPlacementPlugin returns whether the plugin may be configured in profiles.
+func IsPlacementPlugin(name string) bool {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	return placementPlugins[name]
+}
+
+// GetPluginArgumentSchema get the argument schema of the plugin by name
+func GetPluginArgumentSchema(name string) (ArgumentSchema, bool) {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	schema, found := pluginArgumentSchemas[name]
+	return schema, found
+}
+
 // GetPluginBuilder get the pluginbuilder by name
 func GetPluginBuilder(name string) (PluginBuilder, bool) {
 	pluginMutex.RLock()
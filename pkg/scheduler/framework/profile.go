@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"reflect"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+)
+
+// profile is the set of tiers the placement callbacks of a job are dispatched to.
+type profile struct {
+	name  string
+	tiers []conf.Tier
+	// keys maps the plugins opened for the profile to the key their callbacks
+	// are registered with, the other plugins share the instance of the session.
+	keys map[string]string
+}
+
+// key returns the key the callbacks of the plugin are registered with.
+func (p profile) key(plugin string) string {
+	if key, found := p.keys[plugin]; found {
+		return key
+	}
+	return plugin
+}
+
+// profileKey returns the key the plugin opened for the profile is registered with.
+func profileKey(profile, plugin string) string {
+	if len(profile) == 0 {
+		return plugin
+	}
+	return profile + "/" + plugin
+}
+
+// callbackKey returns the key the callbacks of the plugin being opened are registered with.
+func (ssn *Session) callbackKey(plugin string) string {
+	return profileKey(ssn.openingProfile, plugin)
+}
+
+// openProfile opens the plugins of the profile which are not configured with
+// the same arguments in the tiers of the session, the others are shared.
+func (ssn *Session) openProfile(p conf.Profile) {
+	prof := profile{name: p.Name, tiers: p.Tiers, keys: map[string]string{}}
+	ssn.openingProfile = p.Name
+	defer func() { ssn.openingProfile = "" }()
+
+	for _, tier := range p.Tiers {
+		for _, option := range tier.Plugins {
+			if ssn.sharesPlugin(option) {
+				continue
+			}
+			if !IsPlacementPlugin(option.Name) {
+				klog.Warningf("Plugin <%s> of profile <%s> is not a placement plugin, skip it.", option.Name, p.Name)
+				continue
+			}
+			prof.keys[option.Name] = profileKey(p.Name, option.Name)
+			openPlugin(ssn, p.Name, option)
+		}
+	}
+	ssn.profiles[p.Name] = prof
+}
+
+// sharesPlugin returns whether the plugin is configured with the same arguments in the tiers of the session.
+func (ssn *Session) sharesPlugin(option conf.PluginOption) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if plugin.Name == option.Name {
+				return reflect.DeepEqual(plugin.Arguments, option.Arguments)
+			}
+		}
+	}
+	return false
+}
+
+// jobProfileName returns the profile selected by the PodGroup of the job or else by its queue.
+func (ssn *Session) jobProfileName(job *api.JobInfo) string {
+	if job.PodGroup != nil {
+		if name := job.PodGroup.Annotations[api.SchedulingProfileAnnotation]; len(name) != 0 {
+			return name
+		}
+	}
+	if queue, found := ssn.Queues[job.Queue]; found && queue.Queue != nil {
+		return queue.Queue.Annotations[api.SchedulingProfileAnnotation]
+	}
+	return ""
+}
+
+// jobProfile returns the profile of the job, jobs selecting no or an
+// unknown profile use the tiers of the session.
+func (ssn *Session) jobProfile(jobID api.JobID) profile {
+	if len(ssn.profiles) != 0 {
+		if job, found := ssn.Jobs[jobID]; found {
+			if prof, found := ssn.profiles[ssn.jobProfileName(job)]; found {
+				return prof
+			}
+		}
+	}
+	return profile{tiers: ssn.Tiers}
+}
+
+// checkJobProfiles logs the jobs selecting a profile which is not configured.
+func (ssn *Session) checkJobProfiles() {
+	for _, job := range ssn.Jobs {
+		if name := ssn.jobProfileName(job); len(name) != 0 {
+			if _, found := ssn.profiles[name]; !found {
+				klog.Warningf("Scheduling profile <%s> of job <%s/%s> is not configured, using default tiers",
+					name, job.Namespace, job.Name)
+			}
+		}
+	}
+}
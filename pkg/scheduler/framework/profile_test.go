@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+type scorePlugin struct {
+	score float64
+}
+
+func (sp *scorePlugin) Name() string { return "score" }
+
+func (sp *scorePlugin) OnSessionOpen(ssn *Session) {
+	ssn.AddNodeOrderFn(sp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		return sp.score, nil
+	})
+}
+
+func (sp *scorePlugin) OnSessionClose(ssn *Session) {}
+
+func TestSessionProfiles(t *testing.T) {
+	opened := 0
+	RegisterPluginBuilder("score", func(arguments Arguments) Plugin {
+		opened++
+		score, _ := arguments["score"].(int)
+		return &scorePlugin{score: float64(score)}
+	})
+	RegisterPlacementPlugin("score")
+	RegisterPluginBuilder("counter", func(arguments Arguments) Plugin {
+		opened++
+		return &scorePlugin{}
+	})
+	defer CleanupPluginBuilders()
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{Name: "score", EnabledNodeOrder: &trueValue, Arguments: Arguments{"score": 1}},
+			},
+		},
+	}
+	profiles := []conf.Profile{
+		{
+			Name: "spread",
+			Tiers: []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{Name: "score", EnabledNodeOrder: &trueValue, Arguments: Arguments{"score": 5}},
+						// Not a placement plugin, it is not opened for the profile.
+						{Name: "counter", EnabledNodeOrder: &trueValue},
+					},
+				},
+			},
+		},
+		{
+			Name:  "same",
+			Tiers: tiers,
+		},
+	}
+
+	selected := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	selected.Annotations = map[string]string{api.SchedulingProfileAnnotation: "spread"}
+	shared := util.BuildPodGroup("pg4", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	shared.Annotations = map[string]string{api.SchedulingProfileAnnotation: "same"}
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddQueueV1beta1(util.BuildQueueWithAnnos("q2", 1, nil, map[string]string{api.SchedulingProfileAnnotation: "spread"}))
+	sc.AddPodGroupV1beta1(selected)
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg3", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(shared)
+	for _, pg := range []string{"pg1", "pg2", "pg3", "pg4"} {
+		sc.AddPod(util.BuildPod("ns1", "p-"+pg, "", v1.PodPending, api.BuildResourceList("1", "1G"), pg, make(map[string]string), make(map[string]string)))
+	}
+
+	ssn := OpenSessionWithProfiles(sc, tiers, profiles, nil)
+	defer CloseSession(ssn)
+
+	if opened != 2 {
+		t.Errorf("expected the plugin to be opened for the tiers and the spread profile only, opened %d times", opened)
+	}
+
+	expected := map[string]float64{
+		"p-pg1": 5, // selected by the PodGroup
+		"p-pg2": 5, // selected by the queue
+		"p-pg3": 1, // default tiers
+		"p-pg4": 1, // profile sharing the plugin of the tiers
+	}
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			score, err := ssn.NodeOrderFn(task, ssn.Nodes["n1"])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if score != expected[task.Name] {
+				t.Errorf("expected score %v for task %s, got %v", expected[task.Name], task.Name, score)
+			}
+		}
+	}
+}
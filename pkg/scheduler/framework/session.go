@@ -17,7 +17,10 @@ limitations under the License.
 package framework
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -38,6 +41,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -72,6 +76,33 @@ type Session struct {
 	Configurations []conf.Configuration
 	NodeList       []*api.NodeInfo
 
+	// currentAction is the action the decisions are attributed to,
+	// info is filled in when the session is closed.
+	startTime     time.Time
+	currentAction string
+	decisions     []api.SessionDecision
+	tracer        *tracer
+	info          *api.SessionInfo
+
+	// spans are the contexts of the spans started in the session, the last one is the current span.
+	spans []context.Context
+
+	// invalidJobs are the numbers of jobs dropped from the session as they are not valid, by queue and pending reason
+	invalidJobs map[api.QueueID]map[string]int
+
+	// capacitySchedulesApplied is whether the capacity windows of the queues are applied to the session,
+	// capacityWindows are the names of the active windows by queue
+	capacitySchedulesApplied bool
+	capacityWindows          map[api.QueueID]string
+
+	// queueHierarchy is whether the allocated resources of the queues include the ones of their descendants
+	queueHierarchy bool
+
+	// profiles holds the profiles jobs can select by name, openingProfile is
+	// the profile whose plugins are being opened.
+	profiles       map[string]profile
+	openingProfile string
+
 	plugins           map[string]Plugin
 	eventHandlers     []*EventHandler
 	jobOrderFns       map[string]api.CompareFn
@@ -111,9 +142,12 @@ func openSession(cache cache.Cache) *Session {
 		recorder:        cache.EventRecorder(),
 		cache:           cache,
 		informerFactory: cache.SharedInformerFactory(),
+		startTime:       time.Now(),
+		tracer:          newTracer(false),
 
 		TotalResource:  api.EmptyResource(),
 		podGroupStatus: map[api.JobID]scheduling.PodGroupStatus{},
+		invalidJobs:    map[api.QueueID]map[string]int{},
 
 		Jobs:           map[api.JobID]*api.JobInfo{},
 		Nodes:          map[string]*api.NodeInfo{},
@@ -121,6 +155,7 @@ func openSession(cache cache.Cache) *Session {
 		RevocableNodes: map[string]*api.NodeInfo{},
 		Queues:         map[api.QueueID]*api.QueueInfo{},
 
+		profiles:          map[string]profile{},
 		plugins:           map[string]Plugin{},
 		jobOrderFns:       map[string]api.CompareFn{},
 		queueOrderFns:     map[string]api.CompareFn{},
@@ -148,6 +183,7 @@ func openSession(cache cache.Cache) *Session {
 		victimTasksFns:    map[string][]api.VictimTasksFn{},
 		jobStarvingFns:    map[string]api.ValidateFn{},
 	}
+	ssn.StartSpan("Session", tracing.SessionKey.String(string(ssn.UID)))
 
 	snapshot := cache.Snapshot()
 
@@ -173,6 +209,15 @@ func openSession(cache cache.Cache) *Session {
 				}
 			}
 
+			reason := metrics.PendingReasonInvalid
+			if vjr.Reason == vcv1beta1.NotEnoughPodsReason || vjr.Reason == vcv1beta1.NotEnoughPodsOfTaskReason {
+				reason = metrics.PendingReasonGang
+			}
+			if _, found := ssn.invalidJobs[job.Queue]; !found {
+				ssn.invalidJobs[job.Queue] = map[string]int{}
+			}
+			ssn.invalidJobs[job.Queue][reason]++
+
 			delete(ssn.Jobs, job.UID)
 		}
 	}
@@ -201,34 +246,134 @@ func updateQueueStatus(ssn *Session) {
 		allocatedResources[queueID] = &api.Resource{}
 	}
 	for _, job := range ssn.Jobs {
+		path := []api.QueueID{job.Queue}
+		if ssn.queueHierarchy {
+			// the allocated resources of a queue include the ones of its descendants
+			path = queuePath(ssn.Queues, job.Queue)
+		}
 		for _, runningTask := range job.TaskStatusIndex[api.Running] {
-			allocatedResources[job.Queue].Add(runningTask.Resreq)
+			for _, queueID := range path {
+				allocatedResources[queueID].Add(runningTask.Resreq)
+			}
 		}
 	}
 
 	// update queue status
-	for queueID := range ssn.Queues {
+	for queueID, queue := range ssn.Queues {
 		// convert api.Resource to v1.ResourceList
 		var queueStatus = util.ConvertRes2ResList(allocatedResources[queueID]).DeepCopy()
-		if equality.Semantic.DeepEqual(ssn.Queues[queueID].Queue.Status.Allocated, queueStatus) {
+		window := ssn.capacityWindows[queueID]
+		windowChanged := queue.Queue.Annotations[api.ActiveCapacityWindowAnnotation] != window
+		if !windowChanged && equality.Semantic.DeepEqual(queue.Queue.Status.Allocated, queueStatus) {
 			klog.V(5).Infof("Queue <%s> allocated resource keeps equal, no need to update queue status <%v>.",
-				queueID, ssn.Queues[queueID].Queue.Status.Allocated)
+				queueID, queue.Queue.Status.Allocated)
 			continue
 		}
 
-		ssn.Queues[queueID].Queue.Status.Allocated = queueStatus
+		if windowChanged {
+			// The queue is shared with the cache, it is copied before its annotations are changed.
+			queue.Queue = queue.Queue.DeepCopy()
+			if window == "" {
+				delete(queue.Queue.Annotations, api.ActiveCapacityWindowAnnotation)
+			} else {
+				if queue.Queue.Annotations == nil {
+					queue.Queue.Annotations = map[string]string{}
+				}
+				queue.Queue.Annotations[api.ActiveCapacityWindowAnnotation] = window
+			}
+		}
+		queue.Queue.Status.Allocated = queueStatus
 
-		if err := ssn.cache.UpdateQueueStatus(ssn.Queues[queueID]); err != nil {
-			klog.Errorf("failed to update queue <%s> status: %s", ssn.Queues[queueID].Name, err.Error())
+		if err := ssn.cache.UpdateQueueStatus(queue); err != nil {
+			klog.Errorf("failed to update queue <%s> status: %s", queue.Name, err.Error())
 		}
 	}
 }
 
+// updateQueuePendingJobs records the numbers of pending jobs of the queues by the category of the
+// reason they are pending for, it must be called after the status of the jobs is updated.
+func updateQueuePendingJobs(ssn *Session) {
+	pending := map[api.QueueID]map[string]int{}
+	for queueID, reasons := range ssn.invalidJobs {
+		pending[queueID] = map[string]int{}
+		for reason, count := range reasons {
+			pending[queueID][reason] = count
+		}
+	}
+	for _, job := range ssn.Jobs {
+		reason := pendingReason(job)
+		if reason == "" {
+			continue
+		}
+		if _, found := pending[job.Queue]; !found {
+			pending[job.Queue] = map[string]int{}
+		}
+		pending[job.Queue][reason]++
+	}
+
+	for queueID, queue := range ssn.Queues {
+		metrics.UpdateQueuePendingJobs(queue.Name, pending[queueID])
+	}
+}
+
+// pendingReason returns the category of the reason the job is pending for, empty if it is not pending.
+func pendingReason(job *api.JobInfo) string {
+	if job.PodGroup == nil {
+		return ""
+	}
+	if job.PodGroup.Status.Phase == scheduling.PodGroupPending {
+		return metrics.PendingReasonQuota
+	}
+	if job.PodGroup.Status.Phase != scheduling.PodGroupInqueue {
+		return ""
+	}
+
+	predicates := false
+	for _, fitErrors := range job.NodesFitErrors {
+		for _, reason := range fitErrors.Reasons() {
+			if strings.HasPrefix(reason, "Insufficient") || reason == api.NodeResourceFitFailed || reason == api.NodePodNumberExceeded {
+				return metrics.PendingReasonResources
+			}
+			predicates = true
+		}
+	}
+	if predicates {
+		return metrics.PendingReasonPredicates
+	}
+	// The tasks were not tried on the nodes, the queue is overused or cannot allocate them.
+	return metrics.PendingReasonQuota
+}
+
+// EnableQueueHierarchy makes the allocated resources in the status of the queues include the ones
+// of their descendants. It is called by the plugins organizing the queues in a tree by their parent.
+func (ssn *Session) EnableQueueHierarchy() {
+	ssn.queueHierarchy = true
+}
+
+// queuePath returns the queue and its ancestors found in the queues, from the queue up to the top of the hierarchy.
+func queuePath(queues map[api.QueueID]*api.QueueInfo, queueID api.QueueID) []api.QueueID {
+	path := []api.QueueID{queueID}
+	visited := map[api.QueueID]bool{queueID: true}
+	for queue := queues[queueID]; queue != nil && queue.Queue != nil; {
+		parent, found := queues[api.QueueID(queue.Queue.Spec.Parent)]
+		if !found || visited[parent.UID] {
+			break
+		}
+		path = append(path, parent.UID)
+		visited[parent.UID] = true
+		queue = parent
+	}
+	return path
+}
+
 func closeSession(ssn *Session) {
+	ssn.info = sessionInfo(ssn)
+
 	ju := newJobUpdater(ssn)
 	ju.UpdateAll()
 
 	updateQueueStatus(ssn)
+	updateQueuePendingJobs(ssn)
 
 	ssn.Jobs = nil
 	ssn.Nodes = nil
@@ -244,6 +389,30 @@ func closeSession(ssn *Session) {
 	klog.V(3).Infof("Close Session %v", ssn.UID)
 }
 
+// sessionInfo collects the decisions of the session and why jobs were left
+// with pending tasks, it must be called before the session drops its jobs.
+func sessionInfo(ssn *Session) *api.SessionInfo {
+	info := &api.SessionInfo{
+		UID:           ssn.UID,
+		StartTime:     metav1.NewTime(ssn.startTime),
+		EndTime:       metav1.Now(),
+		Decisions:     ssn.decisions,
+		Unschedulable: map[api.JobID]string{},
+		Traces:        ssn.tracer.collect(),
+	}
+	for _, job := range ssn.Jobs {
+		if len(job.TaskStatusIndex[api.Pending]) == 0 {
+			continue
+		}
+		if len(job.JobFitErrors) != 0 {
+			info.Unschedulable[job.UID] = job.JobFitErrors
+		} else if len(job.NodesFitErrors) != 0 {
+			info.Unschedulable[job.UID] = job.FitError()
+		}
+	}
+	return info
+}
+
 func jobStatus(ssn *Session, jobInfo *api.JobInfo) scheduling.PodGroupStatus {
 	status := jobInfo.PodGroup.Status
 
@@ -311,6 +480,7 @@ func (ssn *Session) Pipeline(task *api.TaskInfo, hostname string) error {
 	}
 
 	task.NodeName = hostname
+	ssn.recordDecision(api.PipelineDecision, task, "")
 
 	if node, found := ssn.Nodes[hostname]; found {
 		if err := node.AddTask(task); err != nil {
@@ -415,6 +585,7 @@ func (ssn *Session) dispatch(task *api.TaskInfo) error {
 	if err := ssn.cache.AddBindTask(task); err != nil {
 		return err
 	}
+	ssn.recordDecision(api.BindDecision, task, "")
 
 	// Update status in session
 	if job, found := ssn.Jobs[task.Job]; found {
@@ -438,6 +609,7 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 	if err := ssn.cache.Evict(reclaimee, reason); err != nil {
 		return err
 	}
+	ssn.recordDecision(api.EvictDecision, reclaimee, reason)
 
 	// Update status in session
 	job, found := ssn.Jobs[reclaimee.Job]
@@ -503,6 +675,29 @@ func (ssn *Session) UpdatePodGroupCondition(jobInfo *api.JobInfo, cond *scheduli
 	return nil
 }
 
+// SetCurrentAction sets the action the following decisions are attributed to.
+func (ssn *Session) SetCurrentAction(name string) {
+	ssn.currentAction = name
+}
+
+// Info returns the decisions made in the session, it is only set once the
+// session is closed.
+func (ssn *Session) Info() *api.SessionInfo {
+	return ssn.info
+}
+
+func (ssn *Session) recordDecision(decisionType api.DecisionType, task *api.TaskInfo, reason string) {
+	ssn.decisions = append(ssn.decisions, api.SessionDecision{
+		Action:    ssn.currentAction,
+		Type:      decisionType,
+		Job:       task.Job,
+		Task:      fmt.Sprintf("%s/%s", task.Namespace, task.Name),
+		Node:      task.NodeName,
+		Reason:    reason,
+		Timestamp: metav1.Now(),
+	})
+}
+
 // AddEventHandler add event handlers
 func (ssn *Session) AddEventHandler(eh *EventHandler) {
 	ssn.eventHandlers = append(ssn.eventHandlers, eh)
@@ -542,6 +737,28 @@ func (ssn Session) RecordPodGroupEvent(podGroup *api.PodGroup, eventType, reason
 	ssn.recorder.Eventf(pg, eventType, reason, msg)
 }
 
+// RecordQueueEvent records queue events
+func (ssn Session) RecordQueueEvent(queue *api.QueueInfo, eventType, reason, msg string) {
+	if queue == nil || queue.Queue == nil {
+		return
+	}
+
+	q := &vcv1beta1.Queue{}
+	if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+		klog.Errorf("Error while converting Queue to v1beta1.Queue with error: %v", err)
+		return
+	}
+	ssn.recorder.Event(q, eventType, reason, msg)
+}
+
+// RecordPodEvent records pod events
+func (ssn Session) RecordPodEvent(task *api.TaskInfo, eventType, reason, msg string) {
+	if task == nil || task.Pod == nil {
+		return
+	}
+	ssn.recorder.Event(task.Pod, eventType, reason, msg)
+}
+
 // String return nodes and jobs information in the session
 func (ssn Session) String() string {
 	msg := fmt.Sprintf("Session %v: \n", ssn.UID)
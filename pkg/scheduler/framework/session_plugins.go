@@ -27,127 +27,127 @@ import (
 
 // AddJobOrderFn add job order function
 func (ssn *Session) AddJobOrderFn(name string, cf api.CompareFn) {
-	ssn.jobOrderFns[name] = cf
+	ssn.jobOrderFns[ssn.callbackKey(name)] = cf
 }
 
 // AddQueueOrderFn add queue order function
 func (ssn *Session) AddQueueOrderFn(name string, qf api.CompareFn) {
-	ssn.queueOrderFns[name] = qf
+	ssn.queueOrderFns[ssn.callbackKey(name)] = qf
 }
 
 // AddClusterOrderFn add queue order function
 func (ssn *Session) AddClusterOrderFn(name string, qf api.CompareFn) {
-	ssn.clusterOrderFns[name] = qf
+	ssn.clusterOrderFns[ssn.callbackKey(name)] = qf
 }
 
 // AddTaskOrderFn add task order function
 func (ssn *Session) AddTaskOrderFn(name string, cf api.CompareFn) {
-	ssn.taskOrderFns[name] = cf
+	ssn.taskOrderFns[ssn.callbackKey(name)] = cf
 }
 
 // AddPreemptableFn add preemptable function
 func (ssn *Session) AddPreemptableFn(name string, cf api.EvictableFn) {
-	ssn.preemptableFns[name] = cf
+	ssn.preemptableFns[ssn.callbackKey(name)] = cf
 }
 
 // AddReclaimableFn add Reclaimable function
 func (ssn *Session) AddReclaimableFn(name string, rf api.EvictableFn) {
-	ssn.reclaimableFns[name] = rf
+	ssn.reclaimableFns[ssn.callbackKey(name)] = rf
 }
 
 // AddJobReadyFn add JobReady function
 func (ssn *Session) AddJobReadyFn(name string, vf api.ValidateFn) {
-	ssn.jobReadyFns[name] = vf
+	ssn.jobReadyFns[ssn.callbackKey(name)] = vf
 }
 
 // AddJobPipelinedFn add pipelined function
 func (ssn *Session) AddJobPipelinedFn(name string, vf api.VoteFn) {
-	ssn.jobPipelinedFns[name] = vf
+	ssn.jobPipelinedFns[ssn.callbackKey(name)] = vf
 }
 
 // AddPredicateFn add Predicate function
 func (ssn *Session) AddPredicateFn(name string, pf api.PredicateFn) {
-	ssn.predicateFns[name] = pf
+	ssn.predicateFns[ssn.callbackKey(name)] = pf
 }
 
 // AddPrePredicateFn add PrePredicate function
 func (ssn *Session) AddPrePredicateFn(name string, pf api.PrePredicateFn) {
-	ssn.prePredicateFns[name] = pf
+	ssn.prePredicateFns[ssn.callbackKey(name)] = pf
 }
 
 // AddBestNodeFn add BestNode function
 func (ssn *Session) AddBestNodeFn(name string, pf api.BestNodeFn) {
-	ssn.bestNodeFns[name] = pf
+	ssn.bestNodeFns[ssn.callbackKey(name)] = pf
 }
 
 // AddNodeOrderFn add Node order function
 func (ssn *Session) AddNodeOrderFn(name string, pf api.NodeOrderFn) {
-	ssn.nodeOrderFns[name] = pf
+	ssn.nodeOrderFns[ssn.callbackKey(name)] = pf
 }
 
 // AddBatchNodeOrderFn add Batch Node order function
 func (ssn *Session) AddBatchNodeOrderFn(name string, pf api.BatchNodeOrderFn) {
-	ssn.batchNodeOrderFns[name] = pf
+	ssn.batchNodeOrderFns[ssn.callbackKey(name)] = pf
 }
 
 // AddNodeMapFn add Node map function
 func (ssn *Session) AddNodeMapFn(name string, pf api.NodeMapFn) {
-	ssn.nodeMapFns[name] = pf
+	ssn.nodeMapFns[ssn.callbackKey(name)] = pf
 }
 
 // AddNodeReduceFn add Node reduce function
 func (ssn *Session) AddNodeReduceFn(name string, pf api.NodeReduceFn) {
-	ssn.nodeReduceFns[name] = pf
+	ssn.nodeReduceFns[ssn.callbackKey(name)] = pf
 }
 
 // AddOverusedFn add overused function
 func (ssn *Session) AddOverusedFn(name string, fn api.ValidateFn) {
-	ssn.overusedFns[name] = fn
+	ssn.overusedFns[ssn.callbackKey(name)] = fn
 }
 
 // AddPreemptiveFn add preemptive function
 func (ssn *Session) AddPreemptiveFn(name string, fn api.ValidateFn) {
-	ssn.preemptiveFns[name] = fn
+	ssn.preemptiveFns[ssn.callbackKey(name)] = fn
 }
 
 // AddAllocatableFn add allocatable function
 func (ssn *Session) AddAllocatableFn(name string, fn api.AllocatableFn) {
-	ssn.allocatableFns[name] = fn
+	ssn.allocatableFns[ssn.callbackKey(name)] = fn
 }
 
 // AddJobValidFn add jobvalid function
 func (ssn *Session) AddJobValidFn(name string, fn api.ValidateExFn) {
-	ssn.jobValidFns[name] = fn
+	ssn.jobValidFns[ssn.callbackKey(name)] = fn
 }
 
 // AddJobEnqueueableFn add jobenqueueable function
 func (ssn *Session) AddJobEnqueueableFn(name string, fn api.VoteFn) {
-	ssn.jobEnqueueableFns[name] = fn
+	ssn.jobEnqueueableFns[ssn.callbackKey(name)] = fn
 }
 
 // AddJobEnqueuedFn add jobEnqueued function
 func (ssn *Session) AddJobEnqueuedFn(name string, fn api.JobEnqueuedFn) {
-	ssn.jobEnqueuedFns[name] = fn
+	ssn.jobEnqueuedFns[ssn.callbackKey(name)] = fn
 }
 
 // AddTargetJobFn add targetjob function
 func (ssn *Session) AddTargetJobFn(name string, fn api.TargetJobFn) {
-	ssn.targetJobFns[name] = fn
+	ssn.targetJobFns[ssn.callbackKey(name)] = fn
 }
 
 // AddReservedNodesFn add reservedNodesFn function
 func (ssn *Session) AddReservedNodesFn(name string, fn api.ReservedNodesFn) {
-	ssn.reservedNodesFns[name] = fn
+	ssn.reservedNodesFns[ssn.callbackKey(name)] = fn
 }
 
 // AddVictimTasksFns add victimTasksFns function
 func (ssn *Session) AddVictimTasksFns(name string, fns []api.VictimTasksFn) {
-	ssn.victimTasksFns[name] = fns
+	ssn.victimTasksFns[ssn.callbackKey(name)] = fns
 }
 
 // AddJobStarvingFns add jobStarvingFns function
 func (ssn *Session) AddJobStarvingFns(name string, fn api.ValidateFn) {
-	ssn.jobStarvingFns[name] = fn
+	ssn.jobStarvingFns[ssn.callbackKey(name)] = fn
 }
 
 // Reclaimable invoke reclaimable function of the plugins
@@ -596,12 +596,13 @@ func (ssn *Session) QueueOrderFn(l, r interface{}) bool {
 
 // TaskCompareFns invoke taskorder function of the plugins
 func (ssn *Session) TaskCompareFns(l, r interface{}) int {
-	for _, tier := range ssn.Tiers {
+	profile := ssn.jobProfile(l.(*api.TaskInfo).Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledTaskOrder) {
 				continue
 			}
-			tof, found := ssn.taskOrderFns[plugin.Name]
+			tof, found := ssn.taskOrderFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -629,16 +630,21 @@ func (ssn *Session) TaskOrderFn(l, r interface{}) bool {
 // PredicateFn invoke predicate function of the plugins
 func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
 	predicateStatus := make([]*api.Status, 0)
-	for _, tier := range ssn.Tiers {
+	tracing := ssn.tracing(task)
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledPredicate) {
 				continue
 			}
-			pfn, found := ssn.predicateFns[plugin.Name]
+			pfn, found := ssn.predicateFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
 			status, err := pfn(task, node)
+			if tracing {
+				ssn.tracer.predicate(task, node.Name, plugin.Name, status, err)
+			}
 			predicateStatus = append(predicateStatus, status...)
 			if err != nil {
 				return predicateStatus, err
@@ -650,13 +656,14 @@ func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) ([]*api.
 
 // PrePredicateFn invoke predicate function of the plugins
 func (ssn *Session) PrePredicateFn(task *api.TaskInfo) error {
-	for _, tier := range ssn.Tiers {
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			// we use same option as predicates for they are
 			if !isEnabled(plugin.EnabledPredicate) {
 				continue
 			}
-			pfn, found := ssn.prePredicateFns[plugin.Name]
+			pfn, found := ssn.prePredicateFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -671,12 +678,13 @@ func (ssn *Session) PrePredicateFn(task *api.TaskInfo) error {
 
 // BestNodeFn invoke bestNode function of the plugins
 func (ssn *Session) BestNodeFn(task *api.TaskInfo, nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
-	for _, tier := range ssn.Tiers {
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledBestNode) {
 				continue
 			}
-			pfn, found := ssn.bestNodeFns[plugin.Name]
+			pfn, found := ssn.bestNodeFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -692,12 +700,14 @@ func (ssn *Session) BestNodeFn(task *api.TaskInfo, nodeScores map[float64][]*api
 // NodeOrderFn invoke node order function of the plugins
 func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
 	priorityScore := 0.0
-	for _, tier := range ssn.Tiers {
+	tracing := ssn.tracing(task)
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledNodeOrder) {
 				continue
 			}
-			pfn, found := ssn.nodeOrderFns[plugin.Name]
+			pfn, found := ssn.nodeOrderFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -705,6 +715,9 @@ func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64
 			if err != nil {
 				return 0, err
 			}
+			if tracing {
+				ssn.tracer.score(task, node.Name, plugin.Name, nodeOrderScore, score)
+			}
 			priorityScore += score
 		}
 	}
@@ -714,12 +727,14 @@ func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64
 // BatchNodeOrderFn invoke node order function of the plugins
 func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
 	priorityScore := make(map[string]float64, len(nodes))
-	for _, tier := range ssn.Tiers {
+	tracing := ssn.tracing(task)
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledNodeOrder) {
 				continue
 			}
-			pfn, found := ssn.batchNodeOrderFns[plugin.Name]
+			pfn, found := ssn.batchNodeOrderFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -728,6 +743,9 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 				return nil, err
 			}
 			for nodeName, score := range score {
+				if tracing {
+					ssn.tracer.score(task, nodeName, plugin.Name, batchNodeOrderScore, score)
+				}
 				priorityScore[nodeName] += score
 			}
 		}
@@ -743,19 +761,24 @@ func isEnabled(enabled *bool) bool {
 func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[string]float64, float64, error) {
 	nodeScoreMap := map[string]float64{}
 	var priorityScore float64
-	for _, tier := range ssn.Tiers {
+	tracing := ssn.tracing(task)
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledNodeOrder) {
 				continue
 			}
-			if pfn, found := ssn.nodeOrderFns[plugin.Name]; found {
+			if pfn, found := ssn.nodeOrderFns[profile.key(plugin.Name)]; found {
 				score, err := pfn(task, node)
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
 				}
+				if tracing {
+					ssn.tracer.score(task, node.Name, plugin.Name, nodeOrderScore, score)
+				}
 				priorityScore += score
 			}
-			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
+			if pfn, found := ssn.nodeMapFns[profile.key(plugin.Name)]; found {
 				score, err := pfn(task, node)
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
@@ -770,12 +793,14 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 // NodeOrderReduceFn invoke node order function of the plugins
 func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map[string]k8sframework.NodeScoreList) (map[string]float64, error) {
 	nodeScoreMap := map[string]float64{}
-	for _, tier := range ssn.Tiers {
+	tracing := ssn.tracing(task)
+	profile := ssn.jobProfile(task.Job)
+	for _, tier := range profile.tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledNodeOrder) {
 				continue
 			}
-			pfn, found := ssn.nodeReduceFns[plugin.Name]
+			pfn, found := ssn.nodeReduceFns[profile.key(plugin.Name)]
 			if !found {
 				continue
 			}
@@ -783,6 +808,9 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 				return nodeScoreMap, err
 			}
 			for _, hp := range pluginNodeScoreMap[plugin.Name] {
+				if tracing {
+					ssn.tracer.score(task, hp.Name, plugin.Name, nodeReduceScore, float64(hp.Score))
+				}
 				nodeScoreMap[hp.Name] += float64(hp.Score)
 			}
 		}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestPendingReason(t *testing.T) {
+	job := func(phase scheduling.PodGroupPhase, reasons ...string) *api.JobInfo {
+		fitErrors := api.NewFitErrors()
+		for i, reason := range reasons {
+			fitErrors.SetNodeError(string(rune('a'+i)), errors.New(reason))
+		}
+		pg := &api.PodGroup{}
+		pg.Status.Phase = phase
+		return &api.JobInfo{
+			PodGroup:       pg,
+			NodesFitErrors: map[api.TaskID]*api.FitErrors{"t1": fitErrors},
+		}
+	}
+
+	for _, c := range []struct {
+		name   string
+		job    *api.JobInfo
+		expect string
+	}{
+		{name: "running job", job: job(scheduling.PodGroupRunning, "Insufficient cpu"), expect: ""},
+		{name: "job not enqueued", job: job(scheduling.PodGroupPending), expect: metrics.PendingReasonQuota},
+		{name: "job not allocated", job: job(scheduling.PodGroupInqueue), expect: metrics.PendingReasonQuota},
+		{name: "insufficient resources", job: job(scheduling.PodGroupInqueue, "node(s) had taints", "Insufficient cpu"), expect: metrics.PendingReasonResources},
+		{name: "other predicates", job: job(scheduling.PodGroupInqueue, "node(s) had taints"), expect: metrics.PendingReasonPredicates},
+	} {
+		if got := pendingReason(c.job); got != c.expect {
+			t.Errorf("%s: expected pending reason %q, got %q", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestUpdateQueueStatusHierarchy(t *testing.T) {
+	for _, c := range []struct {
+		name            string
+		hierarchy       bool
+		expectAllocated string
+	}{
+		{name: "hierarchy enabled", hierarchy: true, expectAllocated: "2"},
+		{name: "hierarchy disabled", hierarchy: false, expectAllocated: "1"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := &queueStatusRecorder{queues: map[string]*api.QueueInfo{}}
+			sc := cache.NewCustomMockSchedulerCache("volcano", nil, nil, recorder, nil, nil, nil)
+			child := util.BuildQueue("child", 1, nil)
+			child.Spec.Parent = "parent"
+			sc.AddQueueV1beta1(util.BuildQueue("parent", 1, nil))
+			sc.AddQueueV1beta1(child)
+
+			ssn := OpenSession(sc, nil, nil)
+			defer CloseSession(ssn)
+			if c.hierarchy {
+				ssn.EnableQueueHierarchy()
+			}
+			for _, queue := range []string{"parent", "child"} {
+				pod := util.BuildPod("ns1", queue+"-pod", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+				job := api.NewJobInfo(api.JobID(queue+"-job"), api.NewTaskInfo(pod))
+				job.Queue = api.QueueID(queue)
+				ssn.Jobs[job.UID] = job
+			}
+			updateQueueStatus(ssn)
+			// The jobs have no PodGroup to update when the session is closed.
+			ssn.Jobs = map[api.JobID]*api.JobInfo{}
+
+			parent, found := recorder.queues["parent"]
+			if !found {
+				t.Fatalf("expected the status of the parent queue to be updated")
+			}
+			if cpu := parent.Queue.Status.Allocated[v1.ResourceCPU]; cpu.String() != c.expectAllocated {
+				t.Errorf("expected %s cpu allocated to the parent queue, got %s", c.expectAllocated, cpu.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"volcano.sh/volcano/pkg/scheduler/tracing"
+)
+
+// StartSpan starts a span as a child of the current span of the session, it is
+// the current span of the session until it is ended by EndSpan. The spans are
+// not recorded unless tracing is started, see tracing.Start.
+func (ssn *Session) StartSpan(name string, attrs ...attribute.KeyValue) trace.Span {
+	ctx, span := tracing.Tracer().Start(ssn.SpanContext(), name, trace.WithAttributes(attrs...))
+	ssn.spans = append(ssn.spans, ctx)
+	return span
+}
+
+// EndSpan ends the current span of the session, its parent becomes the current span.
+func (ssn *Session) EndSpan() {
+	if len(ssn.spans) == 0 {
+		return
+	}
+	last := len(ssn.spans) - 1
+	trace.SpanFromContext(ssn.spans[last]).End()
+	ssn.spans = ssn.spans[:last]
+}
+
+// SpanContext returns the context of the current span of the session, e.g.
+// to propagate it to the requests the plugins send.
+func (ssn *Session) SpanContext() context.Context {
+	if len(ssn.spans) == 0 {
+		return context.Background()
+	}
+	return ssn.spans[len(ssn.spans)-1]
+}
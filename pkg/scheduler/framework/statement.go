@@ -103,6 +103,7 @@ func (s *Statement) evict(reclaimee *api.TaskInfo, reason string) error {
 		}
 		return err
 	}
+	s.ssn.recordDecision(api.EvictDecision, reclaimee, reason)
 
 	return nil
 }
@@ -185,6 +186,7 @@ func (s *Statement) Pipeline(task *api.TaskInfo, hostname string) error {
 }
 
 func (s *Statement) pipeline(task *api.TaskInfo) {
+	s.ssn.recordDecision(api.PipelineDecision, task, "")
 }
 
 func (s *Statement) UnPipeline(task *api.TaskInfo) error {
@@ -294,6 +296,7 @@ func (s *Statement) allocate(task *api.TaskInfo) error {
 	if err := s.ssn.cache.AddBindTask(task); err != nil {
 		return err
 	}
+	s.ssn.recordDecision(api.BindDecision, task, "")
 
 	if job, found := s.ssn.Jobs[task.Job]; found {
 		if err := job.UpdateTaskStatus(task, api.Binding); err != nil {
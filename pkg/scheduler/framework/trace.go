@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+type scoreKind int
+
+const (
+	nodeOrderScore scoreKind = iota
+	batchNodeOrderScore
+	nodeReduceScore
+)
+
+// tracer records the predicate verdict and score of each plugin per node for
+// the tasks of traced jobs. Predicates and scores are computed in parallel for
+// the nodes of a task, so all access is serialized.
+type tracer struct {
+	mutex  sync.Mutex
+	all    bool
+	traces map[api.TaskID]*api.TaskTrace
+}
+
+func newTracer(all bool) *tracer {
+	return &tracer{
+		all:    all,
+		traces: map[api.TaskID]*api.TaskTrace{},
+	}
+}
+
+// EnableTrace records the plugin verdicts of all tasks in the session, not
+// only the ones of PodGroups annotated with api.SchedulingTraceAnnotation.
+func (ssn *Session) EnableTrace() {
+	ssn.tracer.all = true
+}
+
+// tracing returns whether the plugin verdicts of the task are recorded.
+func (ssn *Session) tracing(task *api.TaskInfo) bool {
+	if ssn.tracer.all {
+		return true
+	}
+	job, found := ssn.Jobs[task.Job]
+	if !found || job.PodGroup == nil {
+		return false
+	}
+	return job.PodGroup.Annotations[api.SchedulingTraceAnnotation] == "true"
+}
+
+func (t *tracer) node(task *api.TaskInfo, node string) *api.NodeTrace {
+	trace, found := t.traces[task.UID]
+	if !found {
+		trace = &api.TaskTrace{
+			Job:   task.Job,
+			Task:  fmt.Sprintf("%s/%s", task.Namespace, task.Name),
+			Nodes: map[string]*api.NodeTrace{},
+		}
+		t.traces[task.UID] = trace
+	}
+	nodeTrace, found := trace.Nodes[node]
+	if !found {
+		nodeTrace = &api.NodeTrace{
+			Predicates: map[string]api.PredicateTrace{},
+			Scores:     map[string]api.ScoreTrace{},
+		}
+		trace.Nodes[node] = nodeTrace
+	}
+	return nodeTrace
+}
+
+func (t *tracer) predicate(task *api.TaskInfo, node, plugin string, status []*api.Status, err error) {
+	verdict := api.PredicateTrace{Passed: true}
+	var reasons []string
+	for _, s := range status {
+		if s != nil && s.Code != api.Success {
+			verdict.Passed = false
+			reasons = append(reasons, s.Reason)
+		}
+	}
+	if err != nil {
+		verdict.Passed = false
+		reasons = append(reasons, err.Error())
+	}
+	verdict.Reason = strings.Join(reasons, "; ")
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.node(task, node).Predicates[plugin] = verdict
+}
+
+func (t *tracer) score(task *api.TaskInfo, node, plugin string, kind scoreKind, score float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	nodeTrace := t.node(task, node)
+	s := nodeTrace.Scores[plugin]
+	switch kind {
+	case nodeOrderScore:
+		s.NodeOrder = score
+	case batchNodeOrderScore:
+		s.BatchNodeOrder = score
+	case nodeReduceScore:
+		s.NodeReduce = score
+	}
+	nodeTrace.Scores[plugin] = s
+}
+
+// collect returns the traces ordered by task and writes them to the log.
+func (t *tracer) collect() []*api.TaskTrace {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	traces := make([]*api.TaskTrace, 0, len(t.traces))
+	for _, trace := range t.traces {
+		traces = append(traces, trace)
+	}
+	sort.Slice(traces, func(i, j int) bool { return traces[i].Task < traces[j].Task })
+
+	for _, trace := range traces {
+		for name, node := range trace.Nodes {
+			klog.V(3).InfoS("Scheduling trace", "job", trace.Job, "task", trace.Task, "node", name,
+				"predicates", node.Predicates, "scores", node.Scores)
+		}
+	}
+	return traces
+}
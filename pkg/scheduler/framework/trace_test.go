@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+type tracePlugin struct{}
+
+func (tp *tracePlugin) Name() string { return "trace" }
+
+func (tp *tracePlugin) OnSessionOpen(ssn *Session) {
+	ssn.AddPredicateFn(tp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
+		if node.Name == "n2" {
+			return []*api.Status{{Code: api.Unschedulable, Reason: "node n2 is excluded"}}, fmt.Errorf("plugin trace predicates failed")
+		}
+		return []*api.Status{{Code: api.Success}}, nil
+	})
+	ssn.AddNodeOrderFn(tp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		return 10, nil
+	})
+	ssn.AddBatchNodeOrderFn(tp.Name(), func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
+		scores := map[string]float64{}
+		for _, node := range nodes {
+			scores[node.Name] = 5
+		}
+		return scores, nil
+	})
+}
+
+func (tp *tracePlugin) OnSessionClose(ssn *Session) {}
+
+func TestSessionTrace(t *testing.T) {
+	RegisterPluginBuilder("trace", func(Arguments) Plugin { return &tracePlugin{} })
+	defer CleanupPluginBuilders()
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             "trace",
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+				},
+			},
+		},
+	}
+
+	traced := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	traced.Annotations = map[string]string{api.SchedulingTraceAnnotation: "true"}
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(traced)
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)))
+
+	ssn := OpenSession(sc, tiers, nil)
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			nodes := []*api.NodeInfo{ssn.Nodes["n1"], ssn.Nodes["n2"]}
+			for _, node := range nodes {
+				ssn.PredicateFn(task, node)
+				ssn.NodeOrderFn(task, node)
+			}
+			ssn.BatchNodeOrderFn(task, nodes)
+		}
+	}
+	CloseSession(ssn)
+
+	traces := ssn.Info().Traces
+	if len(traces) != 1 || traces[0].Task != "ns1/p1" {
+		t.Fatalf("expected only task ns1/p1 to be traced, got %v", traces)
+	}
+
+	expected := map[string]*api.NodeTrace{
+		"n1": {
+			Predicates: map[string]api.PredicateTrace{"trace": {Passed: true}},
+			Scores:     map[string]api.ScoreTrace{"trace": {NodeOrder: 10, BatchNodeOrder: 5}},
+		},
+		"n2": {
+			Predicates: map[string]api.PredicateTrace{
+				"trace": {Passed: false, Reason: "node n2 is excluded; plugin trace predicates failed"},
+			},
+			Scores: map[string]api.ScoreTrace{"trace": {NodeOrder: 10, BatchNodeOrder: 5}},
+		},
+	}
+	if !reflect.DeepEqual(expected, traces[0].Nodes) {
+		t.Errorf("expected trace %v, got %v", expected, traces[0].Nodes)
+	}
+	if total := traces[0].Nodes["n1"].Scores["trace"].Total(); total != 15 {
+		t.Errorf("expected total score 15, got %v", total)
+	}
+}
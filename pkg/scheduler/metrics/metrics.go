@@ -131,6 +131,14 @@ var (
 		},
 	)
 
+	shuffleEvictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "shuffle_evictions_total",
+			Help:      "Number of victims selected by the shuffle action, by the result. 'dry_run' means the victim would have been evicted, while 'limited' and 'pdb' mean it was spared by the eviction limits or a pod disruption budget.",
+		}, []string{"result"},
+	)
+
 	unscheduleTaskCount = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoNamespace,
@@ -199,6 +207,11 @@ func RegisterPreemptionAttempts() {
 	preemptionAttempts.Inc()
 }
 
+// RegisterShuffleEviction records a victim of the shuffle action by the result
+func RegisterShuffleEviction(result string) {
+	shuffleEvictions.WithLabelValues(result).Inc()
+}
+
 // UpdateUnscheduleTaskCount records total number of unscheduleable tasks
 func UpdateUnscheduleTaskCount(jobID string, taskCount int) {
 	unscheduleTaskCount.WithLabelValues(jobID).Set(float64(taskCount))
@@ -17,10 +17,29 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
 )
 
+// The categories of the reasons jobs are pending for
+const (
+	// PendingReasonQuota means the job is not enqueued or allocated because of the quota of its queue or of the cluster
+	PendingReasonQuota = "quota"
+	// PendingReasonGang means the job has less valid tasks than its minimum member
+	PendingReasonGang = "gang"
+	// PendingReasonResources means the tasks of the job do not fit on the nodes because of insufficient resources
+	PendingReasonResources = "resources"
+	// PendingReasonPredicates means the tasks of the job do not fit on the nodes because of other predicates
+	PendingReasonPredicates = "predicates"
+	// PendingReasonInvalid means the job is not valid for other reasons
+	PendingReasonInvalid = "invalid"
+)
+
+// PendingReasons are the categories of the reasons jobs are pending for
+var PendingReasons = []string{PendingReasonQuota, PendingReasonGang, PendingReasonResources, PendingReasonPredicates, PendingReasonInvalid}
+
 var (
 	queueAllocatedMilliCPU = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -78,6 +97,31 @@ var (
 		}, []string{"queue_name"},
 	)
 
+	queueDecayedUsage = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_decayed_usage",
+			Help:      "Decayed usage for one queue, the share of the cluster it used weighted by recency",
+		}, []string{"queue_name"},
+	)
+
+	queueJobWaitTime = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_job_wait_time_seconds",
+			Help:      "Time jobs of one queue waited from their creation until their minimum number of tasks was allocated",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 18),
+		}, []string{"queue_name"},
+	)
+
+	queuePendingJobs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_pending_jobs",
+			Help:      "Number of pending jobs for one queue, by the category of the reason they are pending for",
+		}, []string{"queue_name", "reason"},
+	)
+
 	queueWeight = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoNamespace,
@@ -150,6 +194,23 @@ func UpdateQueueShare(queueName string, share float64) {
 	queueShare.WithLabelValues(queueName).Set(share)
 }
 
+// UpdateQueueDecayedUsage records decayed usage for one queue
+func UpdateQueueDecayedUsage(queueName string, usage float64) {
+	queueDecayedUsage.WithLabelValues(queueName).Set(usage)
+}
+
+// UpdateQueueJobWaitTime records the wait time of a job of one queue
+func UpdateQueueJobWaitTime(queueName string, duration time.Duration) {
+	queueJobWaitTime.WithLabelValues(queueName).Observe(DurationInSeconds(duration))
+}
+
+// UpdateQueuePendingJobs records the number of pending jobs for one queue by reason
+func UpdateQueuePendingJobs(queueName string, reasons map[string]int) {
+	for _, reason := range PendingReasons {
+		queuePendingJobs.WithLabelValues(queueName, reason).Set(float64(reasons[reason]))
+	}
+}
+
 // UpdateQueueWeight records weight for one queue
 func UpdateQueueWeight(queueName string, weight int32) {
 	queueWeight.WithLabelValues(queueName).Set(float64(weight))
@@ -195,6 +256,11 @@ func DeleteQueueMetrics(queueName string) {
 	queueDeservedMilliCPU.DeleteLabelValues(queueName)
 	queueDeservedMemory.DeleteLabelValues(queueName)
 	queueShare.DeleteLabelValues(queueName)
+	queueDecayedUsage.DeleteLabelValues(queueName)
+	queueJobWaitTime.DeleteLabelValues(queueName)
+	for _, reason := range PendingReasons {
+		queuePendingJobs.DeleteLabelValues(queueName, reason)
+	}
 	queueWeight.DeleteLabelValues(queueName)
 	queueOverused.DeleteLabelValues(queueName)
 	queuePodGroupInqueue.DeleteLabelValues(queueName)
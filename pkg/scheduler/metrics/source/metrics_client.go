@@ -19,8 +19,11 @@ package source
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	listersv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 )
@@ -30,19 +33,109 @@ const (
 	Metrics_Type_Prometheus_Adaptor = "prometheus_adaptor"
 	Metrics_Tpye_Prometheus         = "prometheus"
 	Metrics_Type_Elasticsearch      = "elasticsearch"
+	Metrics_Type_Node_Annotation    = "node_annotation"
+	Metrics_Type_File               = "file"
 )
 
+const (
+	// MetricCPU is the name of the cpu usage metric of the nodes
+	MetricCPU = "cpu"
+	// MetricMemory is the name of the memory usage metric of the nodes
+	MetricMemory = "memory"
+)
+
+// The usage types describe how the usage of a metric over NODE_METRICS_PERIOD is summarized.
+const (
+	UsageTypeAverage = "average"
+	UsageTypeMax     = "max"
+	UsageTypeP95     = "p95"
+	UsageTypeP99     = "p99"
+	// UsageTypeEWMA is the exponentially weighted moving average of the average usage, it is computed
+	// by the scheduler cache over the successive collections of the metrics.
+	UsageTypeEWMA = "ewma"
+
+	defaultEWMAAlpha = 0.3
+)
+
+// NodeMetrics is the average usage of a node over NODE_METRICS_PERIOD, in percent, keyed by metric name.
 type NodeMetrics struct {
 	MetricsTime time.Time
-	CPU         float64
-	Memory      float64
+	Metrics     map[string]float64
+	// Usage is the usage of the node summarized by the other usage types collected from the
+	// source, keyed by usage type and metric name.
+	Usage map[string]map[string]float64
+}
+
+// NewNodeMetrics returns an empty NodeMetrics.
+func NewNodeMetrics() *NodeMetrics {
+	return &NodeMetrics{Metrics: make(map[string]float64), Usage: make(map[string]map[string]float64)}
+}
+
+// setUsage sets the usage of the metric summarized by the usage type.
+func (nm *NodeMetrics) setUsage(usageType, metric string, value float64) {
+	if usageType == UsageTypeAverage {
+		nm.Metrics[metric] = value
+		return
+	}
+	if nm.Usage[usageType] == nil {
+		nm.Usage[usageType] = make(map[string]float64)
+	}
+	nm.Usage[usageType][metric] = value
+}
+
+// UsageTypes returns the usage types collected from the metrics source, the average and the
+// ones configured by "usage.types", e.g. "max,p99". The ewma is not collected from the source.
+func UsageTypes(conf map[string]string) []string {
+	usageTypes := []string{UsageTypeAverage}
+	for _, usageType := range strings.Split(conf["usage.types"], ",") {
+		switch usageType = strings.TrimSpace(usageType); usageType {
+		case UsageTypeMax, UsageTypeP95, UsageTypeP99:
+			usageTypes = append(usageTypes, usageType)
+		case "", UsageTypeAverage, UsageTypeEWMA:
+		default:
+			klog.Warningf("Unknown usage type %s, the supported usage types are %s, %s, %s, %s and %s.", usageType,
+				UsageTypeAverage, UsageTypeMax, UsageTypeP95, UsageTypeP99, UsageTypeEWMA)
+		}
+	}
+	return usageTypes
+}
+
+// EWMAAlpha returns the weight of the latest average usage in the ewma, configured by "ewma.alpha".
+func EWMAAlpha(conf map[string]string) float64 {
+	alpha, err := strconv.ParseFloat(conf["ewma.alpha"], 64)
+	if err != nil || alpha <= 0 || alpha > 1 {
+		return defaultEWMAAlpha
+	}
+	return alpha
+}
+
+// percentile returns the percentile of the usage type, 0 if it is not a percentile.
+func percentile(usageType string) float64 {
+	switch usageType {
+	case UsageTypeP95:
+		return 95
+	case UsageTypeP99:
+		return 99
+	}
+	return 0
+}
+
+// NodeMetricsReport is the usage of a node pushed by an agent, in the annotations of the node or in a file.
+type NodeMetricsReport struct {
+	// Time is when the usage was measured.
+	Time time.Time `json:"time"`
+	// Metrics is the average usage of the node over NODE_METRICS_PERIOD, in percent, keyed by metric name.
+	Metrics map[string]float64 `json:"metrics"`
+	// Usage is the usage of the node summarized by other usage types, e.g. "max" or "p99", keyed by
+	// usage type and metric name.
+	Usage map[string]map[string]float64 `json:"usage,omitempty"`
 }
 
 type MetricsClient interface {
 	NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error
 }
 
-func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (MetricsClient, error) {
+func NewMetricsClient(restConfig *rest.Config, nodeLister listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
 	klog.V(3).Infof("New metrics client begin, metricsConf is %v", metricsConf)
 	metricsType := metricsConf["type"]
 	if metricsType == Metrics_Type_Elasticsearch {
@@ -50,10 +143,36 @@ func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (M
 	} else if metricsType == Metrics_Tpye_Prometheus {
 		return NewPrometheusMetricsClient(metricsConf)
 	} else if metricsType == Metrics_Type_Prometheus_Adaptor {
-		return NewCustomMetricsClient(restConfig)
+		return NewCustomMetricsClient(restConfig, metricsConf)
+	} else if metricsType == Metrics_Type_Node_Annotation {
+		return NewNodeAnnotationMetricsClient(nodeLister, metricsConf), nil
+	} else if metricsType == Metrics_Type_File {
+		return NewFileMetricsClient(metricsConf)
 	} else {
 		return nil, fmt.Errorf("Data cannot be collected from the %s monitoring system. "+
-			"The supported monitoring systems are %s, %s, and %s.",
-			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor)
+			"The supported monitoring systems are %s, %s, %s, %s, and %s.",
+			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor,
+			Metrics_Type_Node_Annotation, Metrics_Type_File)
+	}
+}
+
+// metricsQueries returns the queries of the metrics collected from a source: the default
+// queries overridden or completed by the configuration keys "<prefix>.<metric name>".
+func metricsQueries(conf map[string]string, prefix string, defaults map[string]string) map[string]string {
+	queries := make(map[string]string, len(defaults))
+	for name, query := range defaults {
+		queries[name] = query
+	}
+	for key, query := range conf {
+		name := strings.TrimPrefix(key, prefix+".")
+		if name == key || len(name) == 0 {
+			continue
+		}
+		if len(query) == 0 {
+			delete(queries, name)
+			continue
+		}
+		queries[name] = query
 	}
+	return queries
 }
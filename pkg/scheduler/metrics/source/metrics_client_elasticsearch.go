@@ -35,6 +35,9 @@ const (
 	esCPUUsageField = "host.cpu.usage"
 	// esMemUsageField is the field name of mem usage in the document
 	esMemUsageField = "system.memory.actual.used.pct"
+	// esFieldPrefix is the prefix of the configuration keys of the fields of the metrics, e.g.
+	// "elasticsearch.field.gpu". The fields hold ratios, they are averaged and converted to percents.
+	esFieldPrefix = "elasticsearch.field"
 )
 
 type ElasticsearchMetricsClient struct {
@@ -42,6 +45,8 @@ type ElasticsearchMetricsClient struct {
 	indexName         string
 	es                *elasticsearch.Client
 	hostnameFieldName string
+	fields            map[string]string
+	usageTypes        []string
 }
 
 func NewElasticsearchMetricsClient(conf map[string]string) (*ElasticsearchMetricsClient, error) {
@@ -63,6 +68,11 @@ func NewElasticsearchMetricsClient(conf map[string]string) (*ElasticsearchMetric
 	} else {
 		e.hostnameFieldName = hostNameFieldName
 	}
+	e.fields = metricsQueries(conf, esFieldPrefix, map[string]string{
+		MetricCPU:    esCPUUsageField,
+		MetricMemory: esMemUsageField,
+	})
+	e.usageTypes = UsageTypes(conf)
 	var err error
 	insecureSkipVerify := conf["tls.insecureSkipVerify"] == "true"
 	e.es, err = elasticsearch.NewClient(elasticsearch.Config{
@@ -93,7 +103,13 @@ func (e *ElasticsearchMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMe
 }
 
 func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeName string) (*NodeMetrics, error) {
-	nodeMetrics := &NodeMetrics{}
+	nodeMetrics := NewNodeMetrics()
+	aggs := make(map[string]interface{}, len(e.fields)*len(e.usageTypes))
+	for _, usageType := range e.usageTypes {
+		for name, field := range e.fields {
+			aggs[esAggregationName(usageType, name)] = esAggregation(usageType, field)
+		}
+	}
 	var buf bytes.Buffer
 	query := map[string]interface{}{
 		"size": 0,
@@ -116,18 +132,7 @@ func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeNam
 				},
 			},
 		},
-		"aggs": map[string]interface{}{
-			"cpu": map[string]interface{}{
-				"avg": map[string]interface{}{
-					"field": esCPUUsageField,
-				},
-			},
-			"mem": map[string]interface{}{
-				"avg": map[string]interface{}{
-					"field": esMemUsageField,
-				},
-			},
-		},
+		"aggs": aggs,
 	}
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
 		return nil, err
@@ -142,21 +147,59 @@ func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeNam
 	}
 	defer res.Body.Close()
 	var r struct {
-		Aggregations struct {
-			CPU struct {
-				Value float64 `json:"value"`
-			}
-			Mem struct {
-				Value float64 `json:"value"`
-			}
+		Aggregations map[string]struct {
+			Value float64 `json:"value"`
+			// Values are the percentiles keyed by percent.
+			Values map[string]float64 `json:"values"`
 		} `json:"aggregations"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
 		return nil, err
 	}
 	// The data obtained from Elasticsearch is in decimals and needs to be multiplied by 100.
-	nodeMetrics.CPU = r.Aggregations.CPU.Value * 100
-	nodeMetrics.Memory = r.Aggregations.Mem.Value * 100
+	for _, usageType := range e.usageTypes {
+		for name := range e.fields {
+			aggregation := r.Aggregations[esAggregationName(usageType, name)]
+			value := aggregation.Value
+			for _, percentileValue := range aggregation.Values {
+				value = percentileValue
+			}
+			nodeMetrics.setUsage(usageType, name, value*100)
+		}
+	}
 	nodeMetrics.MetricsTime = time.Now()
 	return nodeMetrics, nil
 }
+
+// esAggregationName returns the name of the aggregation of the metric summarized by the usage type.
+func esAggregationName(usageType, metric string) string {
+	if usageType == UsageTypeAverage {
+		return metric
+	}
+	return usageType + ":" + metric
+}
+
+// esAggregation returns the aggregation summarizing the field by the usage type.
+func esAggregation(usageType, field string) map[string]interface{} {
+	switch usageType {
+	case UsageTypeMax:
+		return map[string]interface{}{
+			"max": map[string]interface{}{
+				"field": field,
+			},
+		}
+	case UsageTypeP95, UsageTypeP99:
+		return map[string]interface{}{
+			"percentiles": map[string]interface{}{
+				"field":    field,
+				"percents": []float64{percentile(usageType)},
+			},
+		}
+	default:
+		return map[string]interface{}{
+			"avg": map[string]interface{}{
+				"field": field,
+			},
+		}
+	}
+}
@@ -16,7 +16,10 @@
 
 package source
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestElasticsearchMetricsClientDefaultIndexName(t *testing.T) {
 	client, err := NewElasticsearchMetricsClient(map[string]string{"address": "http://localhost:9200"})
@@ -37,3 +40,18 @@ func TestElasticsearchMetricsClientCustomIndexName(t *testing.T) {
 		t.Errorf("Custom index name should be custom-index")
 	}
 }
+
+func TestElasticsearchMetricsClientFields(t *testing.T) {
+	client, err := NewElasticsearchMetricsClient(map[string]string{
+		"address":                    "http://localhost:9200",
+		"elasticsearch.field.memory": "",
+		"elasticsearch.field.disk":   "system.diskio.usage.pct",
+	})
+	if err != nil {
+		t.Errorf("Failed to create client: %v", err)
+	}
+	expected := map[string]string{MetricCPU: esCPUUsageField, "disk": "system.diskio.usage.pct"}
+	if !reflect.DeepEqual(client.fields, expected) {
+		t.Errorf("Fields should be %v, got %v", expected, client.fields)
+	}
+}
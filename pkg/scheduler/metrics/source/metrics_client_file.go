@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// FileMetricsClient reads the usage of the nodes from a local yaml or json file, a map of
+// NodeMetricsReport keyed by node name. The file is read again every time the metrics are collected.
+type FileMetricsClient struct {
+	path string
+}
+
+func NewFileMetricsClient(conf map[string]string) (*FileMetricsClient, error) {
+	path := conf["file.path"]
+	if len(path) == 0 {
+		return nil, errors.New("metrics file path is empty")
+	}
+	return &FileMetricsClient{path: path}, nil
+}
+
+func (f *FileMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	reports := map[string]*NodeMetricsReport{}
+	if err := yaml.Unmarshal(data, &reports); err != nil {
+		return err
+	}
+
+	for nodeName, report := range reports {
+		if _, ok := nodeMetricsMap[nodeName]; !ok {
+			klog.Warningf("The node %s information is obtained from file %s, but the volcano cache does not contain the node information.", nodeName, f.path)
+			continue
+		}
+		if report == nil {
+			klog.Warningf("The node %s has no metrics in file %s, skip it.", nodeName, f.path)
+			continue
+		}
+		// The usage is as recent as the file if the time of the report is not set.
+		metricsTime := report.Time
+		if metricsTime.IsZero() {
+			metricsTime = info.ModTime()
+		}
+		nodeMetricsMap[nodeName] = &NodeMetrics{MetricsTime: metricsTime, Metrics: report.Metrics, Usage: report.Usage}
+	}
+	return nil
+}
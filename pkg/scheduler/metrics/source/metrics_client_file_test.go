@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileMetricsClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.yaml")
+	data := `
+n1:
+  time: "2024-01-01T00:00:00Z"
+  metrics:
+    cpu: 30
+    gpu: 90.5
+n2:
+  metrics:
+    memory: 40
+n4:
+n5: null
+unknown:
+  metrics:
+    cpu: 10
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write metrics file: %v", err)
+	}
+	client, err := NewFileMetricsClient(map[string]string{"file.path": path})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": NewNodeMetrics(), "n2": NewNodeMetrics(), "n3": NewNodeMetrics(), "n4": NewNodeMetrics(), "n5": NewNodeMetrics()}
+	if err := client.NodesMetricsAvg(context.Background(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	if n1 := nodeMetricsMap["n1"]; !n1.MetricsTime.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || n1.Metrics["cpu"] != 30 || n1.Metrics["gpu"] != 90.5 {
+		t.Errorf("Unexpected metrics of n1: %v", n1)
+	}
+	if n2 := nodeMetricsMap["n2"]; n2.MetricsTime.IsZero() || n2.Metrics["memory"] != 40 {
+		t.Errorf("Unexpected metrics of n2, the time should be the modification time of the file: %v", n2)
+	}
+	if n3 := nodeMetricsMap["n3"]; !n3.MetricsTime.IsZero() || len(n3.Metrics) != 0 {
+		t.Errorf("Unexpected metrics of n3: %v", n3)
+	}
+	for _, name := range []string{"n4", "n5"} {
+		if metrics := nodeMetricsMap[name]; !metrics.MetricsTime.IsZero() || len(metrics.Metrics) != 0 {
+			t.Errorf("Nodes without report should be skipped, got metrics of %s: %v", name, metrics)
+		}
+	}
+	if _, found := nodeMetricsMap["unknown"]; found {
+		t.Errorf("Nodes not in the cache should be skipped")
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// DefaultNodeMetricsAnnotation is the annotation of the nodes holding their usage, as a json NodeMetricsReport
+const DefaultNodeMetricsAnnotation = "volcano.sh/node-metrics"
+
+// NodeAnnotationMetricsClient reads the usage of the nodes from their annotations, which are
+// kept up to date by an agent running on the nodes, e.g. a daemonset.
+type NodeAnnotationMetricsClient struct {
+	nodeLister listersv1.NodeLister
+	annotation string
+}
+
+func NewNodeAnnotationMetricsClient(nodeLister listersv1.NodeLister, conf map[string]string) *NodeAnnotationMetricsClient {
+	annotation := conf["node_annotation.key"]
+	if len(annotation) == 0 {
+		annotation = DefaultNodeMetricsAnnotation
+	}
+	return &NodeAnnotationMetricsClient{nodeLister: nodeLister, annotation: annotation}
+}
+
+func (na *NodeAnnotationMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	for nodeName := range nodeMetricsMap {
+		node, err := na.nodeLister.Get(nodeName)
+		if err != nil {
+			klog.Warningf("Failed to get node %s: %v", nodeName, err)
+			continue
+		}
+		value, found := node.Annotations[na.annotation]
+		if !found {
+			klog.V(4).Infof("Node %s has no annotation %s", nodeName, na.annotation)
+			continue
+		}
+		report := &NodeMetricsReport{}
+		if err := json.Unmarshal([]byte(value), report); err != nil {
+			klog.Warningf("Failed to parse the annotation %s of node %s: %v", na.annotation, nodeName, err)
+			continue
+		}
+		// The usage is as recent as the annotation if the time of the report is not set.
+		metricsTime := report.Time
+		if metricsTime.IsZero() {
+			klog.V(4).Infof("The annotation %s of node %s has no time, use the time it is read", na.annotation, nodeName)
+			metricsTime = time.Now()
+		}
+		nodeMetricsMap[nodeName] = &NodeMetrics{MetricsTime: metricsTime, Metrics: report.Metrics, Usage: report.Usage}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNodeAnnotationMetricsClient(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, annotation := range map[string]string{
+		"n1": `{"time": "2024-01-01T00:00:00Z", "metrics": {"cpu": 30, "memory": 50}}`,
+		"n2": `not json`,
+		"n5": `{"metrics": {"cpu": 40}}`,
+		"n3": "",
+	} {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if len(annotation) != 0 {
+			node.Annotations = map[string]string{DefaultNodeMetricsAnnotation: annotation}
+		}
+		indexer.Add(node)
+	}
+	client := NewNodeAnnotationMetricsClient(listersv1.NewNodeLister(indexer), map[string]string{})
+
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": NewNodeMetrics(), "n2": NewNodeMetrics(), "n3": NewNodeMetrics(), "n4": NewNodeMetrics(), "n5": NewNodeMetrics()}
+	if err := client.NodesMetricsAvg(context.Background(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	if n1 := nodeMetricsMap["n1"]; n1.MetricsTime.IsZero() || n1.Metrics["cpu"] != 30 || n1.Metrics["memory"] != 50 {
+		t.Errorf("Unexpected metrics of n1: %v", n1)
+	}
+	if n5 := nodeMetricsMap["n5"]; n5.MetricsTime.IsZero() || n5.Metrics["cpu"] != 40 {
+		t.Errorf("Unexpected metrics of n5 without time: %v", n5)
+	}
+	for _, name := range []string{"n2", "n3", "n4"} {
+		if metrics := nodeMetricsMap[name]; !metrics.MetricsTime.IsZero() || len(metrics.Metrics) != 0 {
+			t.Errorf("Unexpected metrics of %s: %v", name, metrics)
+		}
+	}
+}
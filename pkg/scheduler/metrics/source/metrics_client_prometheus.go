@@ -32,9 +32,25 @@ import (
 	"k8s.io/klog/v2"
 )
 
+const (
+	// promQueryPrefix is the prefix of the configuration keys of the PromQL queries of the metrics,
+	// e.g. "prometheus.query.gpu". "$node" and "$period" are replaced in the queries by the name of
+	// the node and NODE_METRICS_PERIOD, and "$over_time(" by the range function of the usage type.
+	// The queries without "$over_time(" only provide the average usage.
+	promQueryPrefix = "prometheus.query"
+	// promOverTime is the placeholder of the range function of the usage type in the queries
+	promOverTime = "$over_time("
+	// promCPUQuery is the default query of the cpu usage
+	promCPUQuery = "$over_time((100 - (avg by (instance) (irate(node_cpu_seconds_total{mode=\"idle\",instance=\"$node\"}[5m])) * 100))[$period:30s])"
+	// promMemQuery is the default query of the memory usage
+	promMemQuery = "100*$over_time(((1-node_memory_MemAvailable_bytes{instance=\"$node\"}/node_memory_MemTotal_bytes{instance=\"$node\"}))[$period:30s])"
+)
+
 type PrometheusMetricsClient struct {
-	address string
-	conf    map[string]string
+	address    string
+	conf       map[string]string
+	queries    map[string]string
+	usageTypes []string
 }
 
 func NewPrometheusMetricsClient(conf map[string]string) (*PrometheusMetricsClient, error) {
@@ -42,7 +58,23 @@ func NewPrometheusMetricsClient(conf map[string]string) (*PrometheusMetricsClien
 	if len(address) == 0 {
 		return nil, errors.New("metrics address is empty")
 	}
-	return &PrometheusMetricsClient{address: address, conf: conf}, nil
+	queries := metricsQueries(conf, promQueryPrefix, map[string]string{
+		MetricCPU:    promCPUQuery,
+		MetricMemory: promMemQuery,
+	})
+	return &PrometheusMetricsClient{address: address, conf: conf, queries: queries, usageTypes: UsageTypes(conf)}, nil
+}
+
+// promRangeFunction returns the range function summarizing the usage by the usage type.
+func promRangeFunction(usageType string) string {
+	switch usageType {
+	case UsageTypeMax:
+		return "max_over_time("
+	case UsageTypeP95, UsageTypeP99:
+		return fmt.Sprintf("quantile_over_time(%v, ", percentile(usageType)/100)
+	default:
+		return "avg_over_time("
+	}
 }
 
 func (p *PrometheusMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
@@ -74,37 +106,37 @@ func (p *PrometheusMetricsClient) NodeMetricsAvg(ctx context.Context, nodeName s
 		return nil, err
 	}
 	v1api := prometheusv1.NewAPI(client)
-	nodeMetrics := &NodeMetrics{}
-	cpuQueryStr := fmt.Sprintf("avg_over_time((100 - (avg by (instance) (irate(node_cpu_seconds_total{mode=\"idle\",instance=\"%s\"}[5m])) * 100))[%s:30s])", nodeName, NODE_METRICS_PERIOD)
-	memQueryStr := fmt.Sprintf("100*avg_over_time(((1-node_memory_MemAvailable_bytes{instance=\"%s\"}/node_memory_MemTotal_bytes{instance=\"%s\"}))[%s:30s])", nodeName, nodeName, NODE_METRICS_PERIOD)
+	nodeMetrics := NewNodeMetrics()
+	replacer := strings.NewReplacer("$node", nodeName, "$period", NODE_METRICS_PERIOD)
 
-	for _, metric := range []string{cpuQueryStr, memQueryStr} {
-		res, warnings, err := v1api.Query(ctx, metric, time.Now())
-		if err != nil {
-			klog.Errorf("Error querying Prometheus: %v", err)
-		}
-		if len(warnings) > 0 {
-			klog.V(3).Infof("Warning querying Prometheus: %v", warnings)
-		}
-		if res == nil || res.String() == "" {
-			klog.Warningf("Warning querying Prometheus: no data found for %s", metric)
-			continue
-		}
-		// plugin.usage only need type pmodel.ValVector in Prometheus.rulues
-		if res.Type() != pmodel.ValVector {
-			continue
-		}
-		// only method res.String() can get data, dataType []pmodel.ValVector, eg: "{k1:v1, ...} => #[value] @#[timespace]\n {k2:v2, ...} => ..."
-		firstRowValVector := strings.Split(res.String(), "\n")[0]
-		rowValues := strings.Split(strings.TrimSpace(firstRowValVector), "=>")
-		value := strings.Split(strings.TrimSpace(rowValues[1]), " ")
-		switch metric {
-		case cpuQueryStr:
-			cpuUsage, _ := strconv.ParseFloat(value[0], 64)
-			nodeMetrics.CPU = cpuUsage
-		case memQueryStr:
-			memUsage, _ := strconv.ParseFloat(value[0], 64)
-			nodeMetrics.Memory = memUsage
+	for _, usageType := range p.usageTypes {
+		rangeFunction := promRangeFunction(usageType)
+		for name, query := range p.queries {
+			if usageType != UsageTypeAverage && !strings.Contains(query, promOverTime) {
+				continue
+			}
+			metric := replacer.Replace(strings.ReplaceAll(query, promOverTime, rangeFunction))
+			res, warnings, err := v1api.Query(ctx, metric, time.Now())
+			if err != nil {
+				klog.Errorf("Error querying Prometheus: %v", err)
+			}
+			if len(warnings) > 0 {
+				klog.V(3).Infof("Warning querying Prometheus: %v", warnings)
+			}
+			if res == nil || res.String() == "" {
+				klog.Warningf("Warning querying Prometheus: no data found for %s", metric)
+				continue
+			}
+			// plugin.usage only need type pmodel.ValVector in Prometheus.rulues
+			if res.Type() != pmodel.ValVector {
+				continue
+			}
+			// only method res.String() can get data, dataType []pmodel.ValVector, eg: "{k1:v1, ...} => #[value] @#[timespace]\n {k2:v2, ...} => ..."
+			firstRowValVector := strings.Split(res.String(), "\n")[0]
+			rowValues := strings.Split(strings.TrimSpace(firstRowValVector), "=>")
+			value := strings.Split(strings.TrimSpace(rowValues[1]), " ")
+			usage, _ := strconv.ParseFloat(value[0], 64)
+			nodeMetrics.setUsage(usageType, name, usage)
 		}
 	}
 	nodeMetrics.MetricsTime = time.Now()
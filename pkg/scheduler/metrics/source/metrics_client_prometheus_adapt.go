@@ -34,17 +34,37 @@ const (
 	CustomNodeCPUUsageAvg = "node_cpu_usage_avg"
 	// CustomNodeMemUsageAvg record name of mem average usage defined in prometheus adapt rules
 	CustomNodeMemUsageAvg = "node_memory_usage_avg"
+	// customMetricPrefix is the prefix of the configuration keys of the custom metrics of the nodes,
+	// e.g. "prometheus_adaptor.metric.gpu". The custom metrics hold ratios, they are converted to percents.
+	// The custom metrics of the other usage types are configured by "prometheus_adaptor.<usage type>.metric.<name>",
+	// e.g. "prometheus_adaptor.p99.metric.gpu", "node_cpu_usage_<usage type>" and "node_memory_usage_<usage type>"
+	// by default.
+	customMetricPrefix = "prometheus_adaptor.metric"
 )
 
 type KMetricsClient struct {
 	customMetricsCli customclient.CustomMetricsClient
+	// metrics are the custom metrics keyed by usage type and metric name
+	metrics map[string]map[string]string
 }
 
 var kMetricsClient *KMetricsClient
 
-func NewCustomMetricsClient(cfg *rest.Config) (*KMetricsClient, error) {
+func NewCustomMetricsClient(cfg *rest.Config, conf map[string]string) (*KMetricsClient, error) {
+	metrics := map[string]map[string]string{
+		UsageTypeAverage: metricsQueries(conf, customMetricPrefix, map[string]string{
+			MetricCPU:    CustomNodeCPUUsageAvg,
+			MetricMemory: CustomNodeMemUsageAvg,
+		}),
+	}
+	for _, usageType := range UsageTypes(conf)[1:] {
+		metrics[usageType] = metricsQueries(conf, "prometheus_adaptor."+usageType+".metric", map[string]string{
+			MetricCPU:    "node_cpu_usage_" + usageType,
+			MetricMemory: "node_memory_usage_" + usageType,
+		})
+	}
 	if kMetricsClient != nil {
-		return kMetricsClient, nil
+		return &KMetricsClient{customMetricsCli: kMetricsClient.customMetricsCli, metrics: metrics}, nil
 	}
 
 	klog.V(3).Infof("Create custom metrics api client")
@@ -56,6 +76,7 @@ func NewCustomMetricsClient(cfg *rest.Config) (*KMetricsClient, error) {
 
 	kMetricsClient = &KMetricsClient{
 		customMetricsCli: customMetricsClient,
+		metrics:          metrics,
 	}
 	return kMetricsClient, nil
 }
@@ -68,30 +89,28 @@ func (km *KMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap ma
 		Kind:  "Node",
 	}
 
-	for _, metricName := range []string{CustomNodeCPUUsageAvg, CustomNodeMemUsageAvg} {
-		metricsValue, err := km.customMetricsCli.RootScopedMetrics().GetForObjects(groupKind, labels.NewSelector(), metricName, labels.NewSelector())
-		if err != nil {
-			klog.Errorf("Failed to query the indicator %s, error is: %v.", metricName, err)
-			return err
-		}
-		for _, metricValue := range metricsValue.Items {
-			nodeName := metricValue.DescribedObject.Name
-			if _, ok := nodeMetricsMap[nodeName]; !ok {
-				klog.Warningf("The node %s information is obtained through the custom metrics API, but the volcano cache does not contain the node information.", nodeName)
-				continue
+	for usageType, metrics := range km.metrics {
+		for name, metricName := range metrics {
+			metricsValue, err := km.customMetricsCli.RootScopedMetrics().GetForObjects(groupKind, labels.NewSelector(), metricName, labels.NewSelector())
+			if err != nil {
+				klog.Errorf("Failed to query the indicator %s, error is: %v.", metricName, err)
+				// Only the average usage is required.
+				if usageType != UsageTypeAverage {
+					continue
+				}
+				return err
 			}
-			klog.V(5).Infof("The current usage information of node %s is %v", nodeName, nodeMetricsMap[nodeName])
-			switch metricName {
-			case CustomNodeCPUUsageAvg:
-				nodeMetricsMap[nodeName].MetricsTime = metricValue.Timestamp.Time
-				nodeMetricsMap[nodeName].CPU = metricValue.Value.AsApproximateFloat64() * 100
-			case CustomNodeMemUsageAvg:
+			for _, metricValue := range metricsValue.Items {
+				nodeName := metricValue.DescribedObject.Name
+				if _, ok := nodeMetricsMap[nodeName]; !ok {
+					klog.Warningf("The node %s information is obtained through the custom metrics API, but the volcano cache does not contain the node information.", nodeName)
+					continue
+				}
+				klog.V(5).Infof("The current usage information of node %s is %v", nodeName, nodeMetricsMap[nodeName])
 				nodeMetricsMap[nodeName].MetricsTime = metricValue.Timestamp.Time
-				nodeMetricsMap[nodeName].Memory = metricValue.Value.AsApproximateFloat64() * 100
-			default:
-				klog.Errorf("Node supports %s and %s metrics, and %s indicates abnormal metrics.", CustomNodeCPUUsageAvg, CustomNodeMemUsageAvg, metricName)
+				nodeMetricsMap[nodeName].setUsage(usageType, name, metricValue.Value.AsApproximateFloat64()*100)
+				klog.V(5).Infof("The updated usage information of node %s is %v.", nodeName, nodeMetricsMap[nodeName])
 			}
-			klog.V(5).Infof("The updated usage information of node %s is %v.", nodeName, nodeMetricsMap[nodeName])
 		}
 	}
 
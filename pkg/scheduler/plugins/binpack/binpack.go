@@ -85,6 +85,15 @@ type binpackPlugin struct {
 	weight priorityWeight
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	BinpackWeight:                framework.IntArgument,
+	BinpackCPU:                   framework.IntArgument,
+	BinpackMemory:                framework.IntArgument,
+	BinpackResources:             framework.StringArgument,
+	BinpackResourcesPrefix + "*": framework.IntArgument,
+}
+
 // New function returns prioritizePlugin object
 func New(aruguments framework.Arguments) framework.Plugin {
 	weight := calculateWeight(aruguments)
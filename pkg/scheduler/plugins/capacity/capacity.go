@@ -17,7 +17,7 @@ limitations under the License.
 package capacity
 
 import (
-	"math"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -39,6 +39,8 @@ type capacityPlugin struct {
 	totalGuarantee *api.Resource
 
 	queueOpts map[api.QueueID]*queueAttr
+	// hierarchyEnabled is whether the queues are organized in a tree by their parent
+	hierarchyEnabled bool
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 }
@@ -47,6 +49,11 @@ type queueAttr struct {
 	queueID api.QueueID
 	name    string
 	share   float64
+	// parent is the attributes of the parent queue, nil for the queues at the top of the hierarchy.
+	// The resources of a queue include the ones of its descendants.
+	parent *queueAttr
+	// childrenGuarantee is the sum of the guarantees of the child queues
+	childrenGuarantee *api.Resource
 
 	deserved  *api.Resource
 	allocated *api.Resource
@@ -76,73 +83,44 @@ func (cp *capacityPlugin) Name() string {
 }
 
 func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
+	// The capacity of the queues is the one of their active capacity window if any.
+	ssn.ApplyQueueCapacitySchedules()
+
+	cp.hierarchyEnabled = cp.HierarchyEnabled(ssn)
+	if cp.hierarchyEnabled {
+		ssn.EnableQueueHierarchy()
+	}
+
 	// Prepare scheduling data for this session.
 	cp.totalResource.Add(ssn.TotalResource)
 
 	klog.V(4).Infof("The total resource is <%v>", cp.totalResource)
-	for _, queue := range ssn.Queues {
-		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
-			continue
-		}
-		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-		cp.totalGuarantee.Add(guarantee)
-	}
-	klog.V(4).Infof("The total guarantee resource is <%v>", cp.totalGuarantee)
-	// Build attributes for Queues.
+	// Build attributes for Queues with jobs and their ancestors.
 	for _, job := range ssn.Jobs {
 		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
-		if _, found := cp.queueOpts[job.Queue]; !found {
-			queue := ssn.Queues[job.Queue]
-			attr := &queueAttr{
-				queueID: queue.UID,
-				name:    queue.Name,
-
-				deserved:  api.NewResource(queue.Queue.Spec.Deserved),
-				allocated: api.EmptyResource(),
-				request:   api.EmptyResource(),
-				elastic:   api.EmptyResource(),
-				inqueue:   api.EmptyResource(),
-				guarantee: api.EmptyResource(),
-			}
-			if len(queue.Queue.Spec.Capability) != 0 {
-				attr.capability = api.NewResource(queue.Queue.Spec.Capability)
-				if attr.capability.MilliCPU <= 0 {
-					attr.capability.MilliCPU = math.MaxFloat64
-				}
-				if attr.capability.Memory <= 0 {
-					attr.capability.Memory = math.MaxFloat64
-				}
-			}
-			if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
-				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-			}
-			realCapability := cp.totalResource.Clone().Sub(cp.totalGuarantee).Add(attr.guarantee)
-			if attr.capability == nil {
-				attr.realCapability = realCapability
-			} else {
-				realCapability.MinDimensionResource(attr.capability, api.Infinity)
-				attr.realCapability = realCapability
-			}
-			cp.queueOpts[job.Queue] = attr
-			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
-		}
+		path := cp.buildQueueAttr(ssn, ssn.Queues[job.Queue]).path()
 
-		attr := cp.queueOpts[job.Queue]
 		for status, tasks := range job.TaskStatusIndex {
 			if api.AllocatedStatus(status) {
 				for _, t := range tasks {
-					attr.allocated.Add(t.Resreq)
-					attr.request.Add(t.Resreq)
+					for _, attr := range path {
+						attr.allocated.Add(t.Resreq)
+						attr.request.Add(t.Resreq)
+					}
 				}
 			} else if status == api.Pending {
 				for _, t := range tasks {
-					attr.request.Add(t.Resreq)
+					for _, attr := range path {
+						attr.request.Add(t.Resreq)
+					}
 				}
 			}
 		}
 
 		if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue {
-			attr.inqueue.Add(job.GetMinResources())
+			for _, attr := range path {
+				attr.inqueue.Add(job.GetMinResources())
+			}
 		}
 
 		// calculate inqueue resource for running jobs
@@ -152,24 +130,41 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			job.PodGroup.Spec.MinResources != nil &&
 			int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
 			inqueued := util.GetInqueueResource(job, job.Allocated)
-			attr.inqueue.Add(inqueued)
+			for _, attr := range path {
+				attr.inqueue.Add(inqueued)
+			}
+		}
+		for _, attr := range path {
+			attr.elastic.Add(job.GetElasticResources())
 		}
-		attr.elastic.Add(job.GetElasticResources())
 		klog.V(5).Infof("Queue %s allocated <%s> request <%s> inqueue <%s> elastic <%s>",
-			attr.name, attr.allocated.String(), attr.request.String(), attr.inqueue.String(), attr.elastic.String())
+			path[0].name, path[0].allocated.String(), path[0].request.String(), path[0].inqueue.String(), path[0].elastic.String())
 	}
 
-	for _, attr := range cp.queueOpts {
-		if attr.realCapability != nil {
-			attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
+	// The guarantees of the child queues are taken from the resources of their parent,
+	// the guarantees of the queues at the top of the hierarchy from the total resource.
+	for _, queue := range ssn.Queues {
+		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
+			continue
+		}
+		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+		if !cp.hierarchyEnabled {
+			cp.totalGuarantee.Add(guarantee)
+			continue
+		}
+		// The attributes of the queue and its ancestors are built even if they have no jobs,
+		// so that the guarantee is taken from its parent.
+		if parent := cp.buildQueueAttr(ssn, queue).parent; parent != nil {
+			parent.childrenGuarantee.Add(guarantee)
+		} else {
+			cp.totalGuarantee.Add(guarantee)
 		}
-		// When scalar resource not specified in deserved such as "pods", we should skip it and consider deserved resource as infinity.
-		attr.deserved.MinDimensionResource(attr.request, api.Infinity)
+	}
+	klog.V(4).Infof("The total guarantee resource is <%v>", cp.totalGuarantee)
 
-		attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
-		cp.updateShare(attr)
-		klog.V(4).Infof("The attributes of queue <%s> in capacity: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
-			attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
+	updated := map[api.QueueID]bool{}
+	for _, attr := range cp.queueOpts {
+		cp.updateDeserved(ssn, attr, updated)
 	}
 
 	// Record metrics
@@ -202,44 +197,71 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddQueueOrderFn(cp.Name(), func(l, r interface{}) int {
 		lv := l.(*api.QueueInfo)
 		rv := r.(*api.QueueInfo)
+		lAttr, rAttr := cp.queueOpts[lv.UID], cp.queueOpts[rv.UID]
 
-		if cp.queueOpts[lv.UID].share == cp.queueOpts[rv.UID].share {
-			return 0
-		}
-
-		if cp.queueOpts[lv.UID].share < cp.queueOpts[rv.UID].share {
-			return -1
+		// Queues in different subtrees are ordered by the shares of their subtrees first.
+		lPath, rPath := divergence(lAttr, rAttr)
+		if len(lPath) > 0 && len(rPath) > 0 {
+			if order := compareShare(lPath[len(lPath)-1], rPath[len(rPath)-1]); order != 0 {
+				return order
+			}
 		}
 
-		return 1
+		return compareShare(lAttr, rAttr)
 	})
 
 	ssn.AddReclaimableFn(cp.Name(), func(reclaimer *api.TaskInfo, reclaimees []*api.TaskInfo) ([]*api.TaskInfo, int) {
 		var victims []*api.TaskInfo
 		allocations := map[api.QueueID]*api.Resource{}
+		reclaimerAttr := cp.queueOpts[ssn.Jobs[reclaimer.Job].Queue]
 
+		// Reclaim from the closest queues in the hierarchy first: the siblings of the queue
+		// of the reclaimer, then its cousins, and so on up to the top of the hierarchy.
+		distances := []int{}
+		reclaimeesByDistance := map[int][]*api.TaskInfo{}
 		for _, reclaimee := range reclaimees {
-			job := ssn.Jobs[reclaimee.Job]
-			attr := cp.queueOpts[job.Queue]
-
-			if _, found := allocations[job.Queue]; !found {
-				allocations[job.Queue] = attr.allocated.Clone()
+			// The distance is the number of levels from the queue of the reclaimer up to the common ancestor.
+			path, _ := divergence(reclaimerAttr, cp.queueOpts[ssn.Jobs[reclaimee.Job].Queue])
+			if _, found := reclaimeesByDistance[len(path)]; !found {
+				distances = append(distances, len(path))
 			}
-			allocated := allocations[job.Queue]
-			if allocated.LessPartly(reclaimer.Resreq, api.Zero) {
-				klog.V(3).Infof("Failed to allocate resource for Task <%s/%s> in Queue <%s>, not enough resource.",
-					reclaimee.Namespace, reclaimee.Name, job.Queue)
-				continue
+			reclaimeesByDistance[len(path)] = append(reclaimeesByDistance[len(path)], reclaimee)
+		}
+		sort.Ints(distances)
+
+		reclaimed := api.EmptyResource()
+		for _, distance := range distances {
+			// Stop once the victims in the closer queues free enough resources.
+			if len(victims) > 0 {
+				futureIdle := reclaimed.Clone()
+				if node, found := ssn.Nodes[victims[0].NodeName]; found {
+					futureIdle.Add(node.FutureIdle())
+				}
+				if reclaimer.InitResreq.LessEqual(futureIdle, api.Zero) {
+					break
+				}
 			}
 
-			exceptReclaimee := allocated.Clone().Sub(reclaimee.Resreq)
-			// When scalar resource not specified in deserved such as "pods", we should skip it and consider it as infinity,
-			// so the following first condition will be true and the current queue will not be reclaimed.
-			if allocated.LessEqual(attr.deserved, api.Infinity) || !attr.guarantee.LessEqual(exceptReclaimee, api.Zero) {
-				continue
+			for _, reclaimee := range reclaimeesByDistance[distance] {
+				job := ssn.Jobs[reclaimee.Job]
+				reclaimerPath, reclaimeePath := divergence(reclaimerAttr, cp.queueOpts[job.Queue])
+
+				// The queues of the reclaimer can only reclaim outside of themselves if they are not overused.
+				if overused := overusedQueue(reclaimerPath); overused != nil {
+					klog.V(3).Infof("Queue <%s> is overused, can not reclaim Task <%s/%s> in Queue <%s>.",
+						overused.name, reclaimee.Namespace, reclaimee.Name, job.Queue)
+					continue
+				}
+
+				if !reclaimableFromQueues(reclaimer, reclaimee, reclaimeePath, allocations) {
+					continue
+				}
+				for _, attr := range reclaimeePath {
+					allocations[attr.queueID].Sub(reclaimee.Resreq)
+				}
+				victims = append(victims, reclaimee)
+				reclaimed.Add(reclaimee.Resreq)
 			}
-			allocated.Sub(reclaimee.Resreq)
-			victims = append(victims, reclaimee)
 		}
 		klog.V(4).InfoS("Victims from capacity plugin", "victims", victims, "reclaimer", reclaimer)
 		return victims, util.Permit
@@ -260,16 +282,17 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	ssn.AddAllocatableFn(cp.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
-		attr := cp.queueOpts[queue.UID]
-
-		free, _ := attr.realCapability.Diff(attr.allocated, api.Zero)
-		allocatable := candidate.Resreq.LessEqual(free, api.Zero)
-		if !allocatable {
-			klog.V(3).Infof("Queue <%v>: realCapability <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
-				queue.Name, attr.realCapability, attr.allocated, candidate.Name, candidate.Resreq)
+		// The candidate must fit in the queue and in all its ancestors.
+		for _, attr := range cp.queueOpts[queue.UID].path() {
+			free, _ := attr.realCapability.Diff(attr.allocated, api.Zero)
+			if !candidate.Resreq.LessEqual(free, api.Zero) {
+				klog.V(3).Infof("Queue <%v>: realCapability <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
+					attr.name, attr.realCapability, attr.allocated, candidate.Name, candidate.Resreq)
+				return false
+			}
 		}
 
-		return allocatable
+		return true
 	})
 
 	ssn.AddJobEnqueueableFn(cp.Name(), func(obj interface{}) int {
@@ -288,24 +311,34 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			klog.V(4).Infof("job %s MinResources is null.", job.Name)
 			return util.Permit
 		}
-		minReq := job.GetMinResources()
 
-		klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
-			job.Name, minReq.String(), queue.Name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
-		// The queue resource quota limit has not reached
-		r := minReq.Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
-		rr := attr.realCapability.Clone()
+		// The resource quota limit of the queue and of all its ancestors must not be reached.
+		path := attr.path()
+		inqueue := true
+		for _, attr := range path {
+			minReq := job.GetMinResources()
 
-		for name := range rr.ScalarResources {
-			if _, ok := r.ScalarResources[name]; !ok {
-				delete(rr.ScalarResources, name)
+			klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
+				job.Name, minReq.String(), attr.name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
+			r := minReq.Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
+			rr := attr.realCapability.Clone()
+
+			for name := range rr.ScalarResources {
+				if _, ok := r.ScalarResources[name]; !ok {
+					delete(rr.ScalarResources, name)
+				}
 			}
-		}
 
-		inqueue := r.LessEqual(rr, api.Infinity)
+			if !r.LessEqual(rr, api.Infinity) {
+				inqueue = false
+				break
+			}
+		}
 		klog.V(5).Infof("job %s inqueue %v", job.Name, inqueue)
 		if inqueue {
-			attr.inqueue.Add(job.GetMinResources())
+			for _, attr := range path {
+				attr.inqueue.Add(job.GetMinResources())
+			}
 			return util.Permit
 		}
 		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
@@ -316,25 +349,27 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := cp.queueOpts[job.Queue]
-			attr.allocated.Add(event.Task.Resreq)
-			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory)
+			for _, attr := range cp.queueOpts[job.Queue].path() {
+				attr.allocated.Add(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory)
 
-			cp.updateShare(attr)
+				cp.updateShare(attr)
+			}
 
 			klog.V(4).Infof("Capacity AllocateFunc: task <%v/%v>, resreq <%v>,  share <%v>",
-				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
+				event.Task.Namespace, event.Task.Name, event.Task.Resreq, cp.queueOpts[job.Queue].share)
 		},
 		DeallocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := cp.queueOpts[job.Queue]
-			attr.allocated.Sub(event.Task.Resreq)
-			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory)
+			for _, attr := range cp.queueOpts[job.Queue].path() {
+				attr.allocated.Sub(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory)
 
-			cp.updateShare(attr)
+				cp.updateShare(attr)
+			}
 
 			klog.V(4).Infof("Capacity EvictFunc: task <%v/%v>, resreq <%v>,  share <%v>",
-				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
+				event.Task.Namespace, event.Task.Name, event.Task.Resreq, cp.queueOpts[job.Queue].share)
 		},
 	})
 }
@@ -22,7 +22,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
-	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
 	"volcano.sh/volcano/pkg/scheduler/actions/reclaim"
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -37,7 +36,6 @@ func Test_capacityPlugin_OnSessionOpen(t *testing.T) {
 	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New}
 	trueValue := true
 	actions := []framework.Action{allocate.New(), reclaim.New()}
-	options.Default()
 
 	// nodes
 	n1 := util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{"selector": "worker"})
@@ -75,6 +73,36 @@ func Test_capacityPlugin_OnSessionOpen(t *testing.T) {
 	queue3 := util.BuildQueueWithResourcesQuantity("q3", api.BuildResourceList("2", "4Gi"), nil)
 	queue4 := util.BuildQueueWithResourcesQuantity("q4", api.BuildResourceList("2", "4Gi"), nil)
 
+	// resources for test case 3
+	// pod
+	p8 := util.BuildPod("ns1", "p8", "n1", corev1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg7", make(map[string]string), make(map[string]string))
+	p9 := util.BuildPod("ns1", "p9", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg8", make(map[string]string), make(map[string]string))
+	// podgroup
+	pg7 := util.BuildPodGroup("pg7", "ns1", "q6", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg8 := util.BuildPodGroup("pg8", "ns1", "q6", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	// queue
+	queue5 := util.BuildQueueWithResourcesQuantity("q5", nil, api.BuildResourceList("1.5", "1.5Gi"))
+	queue6 := util.BuildQueueWithResourcesQuantity("q6", nil, nil)
+	queue6.Spec.Parent = "q5"
+
+	// resources for test case 4
+	n3 := util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	// pod
+	p10 := util.BuildPod("ns1", "p10", "n3", corev1.PodRunning, api.BuildResourceList("2", "4Gi"), "pg9", make(map[string]string), make(map[string]string))
+	p11 := util.BuildPod("ns1", "p11", "n3", corev1.PodRunning, api.BuildResourceList("2", "4Gi"), "pg10", make(map[string]string), make(map[string]string))
+	p12 := util.BuildPod("ns1", "p12", "", corev1.PodPending, api.BuildResourceList("2", "4Gi"), "pg11", make(map[string]string), make(map[string]string))
+	// podgroup
+	pg9 := util.BuildPodGroup("pg9", "ns1", "q9", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg10 := util.BuildPodGroup("pg10", "ns1", "q10", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg11 := util.BuildPodGroup("pg11", "ns1", "q8", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	// queue
+	queue7 := util.BuildQueueWithResourcesQuantity("q7", api.BuildResourceList("4", "8Gi"), nil)
+	queue8 := util.BuildQueueWithResourcesQuantity("q8", api.BuildResourceList("2", "4Gi"), nil)
+	queue8.Spec.Parent = "q7"
+	queue9 := util.BuildQueueWithResourcesQuantity("q9", nil, nil)
+	queue9.Spec.Parent = "q7"
+	queue10 := util.BuildQueueWithResourcesQuantity("q10", nil, nil)
+
 	tests := []uthelper.TestCommonStruct{
 		{
 			Name:      "case0: Pod allocatable when queue has not exceed capability",
@@ -110,6 +138,28 @@ func Test_capacityPlugin_OnSessionOpen(t *testing.T) {
 			ExpectEvicted:  []string{"ns1/p6"},
 			ExpectEvictNum: 1,
 		},
+		{
+			Name:           "case3: Pod not allocatable when parent queue exceed queue capability",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p8, p9},
+			Nodes:          []*corev1.Node{n1, n2},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg7, pg8},
+			Queues:         []*schedulingv1beta1.Queue{queue5, queue6},
+			ExpectBindsNum: 0,
+		},
+		{
+			Name:      "case4: Reclaim from sibling queues before other queues",
+			Plugins:   plugins,
+			Pods:      []*corev1.Pod{p10, p11, p12},
+			Nodes:     []*corev1.Node{n3},
+			PodGroups: []*schedulingv1beta1.PodGroup{pg9, pg10, pg11},
+			Queues:    []*schedulingv1beta1.Queue{queue7, queue8, queue9, queue10},
+			ExpectPipeLined: map[string][]string{
+				"ns1/pg11": {"n3"},
+			},
+			ExpectEvicted:  []string{"ns1/p10"},
+			ExpectEvictNum: 1,
+		},
 	}
 
 	tiers := []conf.Tier{
@@ -120,6 +170,7 @@ func Test_capacityPlugin_OnSessionOpen(t *testing.T) {
 					EnabledAllocatable: &trueValue,
 					EnablePreemptive:   &trueValue,
 					EnabledReclaimable: &trueValue,
+					EnabledHierarchy:   &trueValue,
 				},
 				{
 					Name:             predicates.PluginName,
@@ -139,3 +190,50 @@ func Test_capacityPlugin_OnSessionOpen(t *testing.T) {
 		})
 	}
 }
+
+func TestGuaranteeWithoutHierarchy(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New}
+	trueValue := true
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	p1 := util.BuildPod("ns1", "p1", "n1", corev1.PodRunning, api.BuildResourceList("2", "4Gi"), "pg1", make(map[string]string), make(map[string]string))
+	p2 := util.BuildPod("ns1", "p2", "", corev1.PodPending, api.BuildResourceList("1", "2Gi"), "pg2", make(map[string]string), make(map[string]string))
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue1 := util.BuildQueueWithResourcesQuantity("q1", nil, nil)
+	queue2 := util.BuildQueueWithResourcesQuantity("q2", nil, nil)
+	// The parent of q3 is ignored without hierarchy, its guarantee is taken from the total resource.
+	queue3 := util.BuildQueueWithResourcesQuantity("q3", nil, nil)
+	queue3.Spec.Parent = "q2"
+	queue3.Spec.Guarantee.Resource = api.BuildResourceList("2", "4Gi")
+
+	test := uthelper.TestCommonStruct{
+		Name:           "guarantee of a queue with a parent is protected without hierarchy",
+		Plugins:        plugins,
+		Pods:           []*corev1.Pod{p1, p2},
+		Nodes:          []*corev1.Node{n1},
+		PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+		Queues:         []*schedulingv1beta1.Queue{queue1, queue2, queue3},
+		ExpectBindsNum: 0,
+	}
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+			},
+		},
+	}
+	test.RegisterSession(tiers, nil)
+	defer test.Close()
+	test.Run([]framework.Action{allocate.New()})
+	if err := test.CheckAll(0); err != nil {
+		t.Fatal(err)
+	}
+}
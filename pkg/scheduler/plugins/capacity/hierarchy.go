@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"math"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func newQueueAttr(queue *api.QueueInfo) *queueAttr {
+	attr := &queueAttr{
+		queueID: queue.UID,
+		name:    queue.Name,
+
+		deserved:          api.NewResource(queue.Queue.Spec.Deserved),
+		allocated:         api.EmptyResource(),
+		request:           api.EmptyResource(),
+		elastic:           api.EmptyResource(),
+		inqueue:           api.EmptyResource(),
+		guarantee:         api.EmptyResource(),
+		childrenGuarantee: api.EmptyResource(),
+	}
+	if len(queue.Queue.Spec.Capability) != 0 {
+		attr.capability = api.NewResource(queue.Queue.Spec.Capability)
+		if attr.capability.MilliCPU <= 0 {
+			attr.capability.MilliCPU = math.MaxFloat64
+		}
+		if attr.capability.Memory <= 0 {
+			attr.capability.Memory = math.MaxFloat64
+		}
+	}
+	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
+		attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	}
+	return attr
+}
+
+// HierarchyEnabled returns if hierarchy is enabled
+func (cp *capacityPlugin) HierarchyEnabled(ssn *framework.Session) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if plugin.Name != PluginName {
+				continue
+			}
+			return plugin.EnabledHierarchy != nil && *plugin.EnabledHierarchy
+		}
+	}
+	return false
+}
+
+// buildQueueAttr returns the attributes of the queue, the attributes of its ancestors are built along.
+// A queue whose parent is not found, or any queue if hierarchy is not enabled, is at the top of the hierarchy.
+func (cp *capacityPlugin) buildQueueAttr(ssn *framework.Session, queue *api.QueueInfo) *queueAttr {
+	if attr, found := cp.queueOpts[queue.UID]; found {
+		return attr
+	}
+	attr := newQueueAttr(queue)
+	cp.queueOpts[queue.UID] = attr
+	klog.V(4).Infof("Added Queue <%s> attributes.", queue.UID)
+
+	parentName := queue.Queue.Spec.Parent
+	if !cp.hierarchyEnabled || parentName == "" {
+		return attr
+	}
+	parent, found := ssn.Queues[api.QueueID(parentName)]
+	if !found {
+		klog.Warningf("Parent <%s> of Queue <%s> is not found, consider it at the top of the hierarchy.", parentName, queue.Name)
+		return attr
+	}
+	parentAttr := cp.buildQueueAttr(ssn, parent)
+	for ancestor := parentAttr; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == attr {
+			klog.Warningf("Queue <%s> is an ancestor of its parent <%s>, consider it at the top of the hierarchy.", queue.Name, parentName)
+			return attr
+		}
+	}
+	attr.parent = parentAttr
+	return attr
+}
+
+// path returns the queue and its ancestors, from the queue up to the top of the hierarchy.
+func (attr *queueAttr) path() []*queueAttr {
+	var path []*queueAttr
+	for ancestor := attr; ancestor != nil; ancestor = ancestor.parent {
+		path = append(path, ancestor)
+	}
+	return path
+}
+
+// divergence returns the paths of the queues up to their lowest common ancestor, excluded.
+// The last queues of the paths are siblings, or at the top of the hierarchy.
+func divergence(l, r *queueAttr) ([]*queueAttr, []*queueAttr) {
+	lPath, rPath := l.path(), r.path()
+	i, j := len(lPath), len(rPath)
+	for i > 0 && j > 0 && lPath[i-1] == rPath[j-1] {
+		i--
+		j--
+	}
+	return lPath[:i], rPath[:j]
+}
+
+// updateRealCapability sets the real capability of the queue: the resources of its parent which
+// are not guaranteed to its siblings, limited by its capability.
+func (cp *capacityPlugin) updateRealCapability(attr *queueAttr) {
+	if attr.realCapability != nil {
+		return
+	}
+
+	var realCapability *api.Resource
+	if attr.parent == nil {
+		realCapability = cp.totalResource.Clone().Sub(cp.totalGuarantee).Add(attr.guarantee)
+	} else {
+		cp.updateRealCapability(attr.parent)
+		realCapability = attr.parent.realCapability.Clone().SubWithoutAssert(attr.parent.childrenGuarantee).Add(attr.guarantee)
+	}
+	if attr.capability != nil {
+		realCapability.MinDimensionResource(attr.capability, api.Infinity)
+	}
+	attr.realCapability = realCapability
+}
+
+// updateDeserved sets the deserved resources of the queue after the ones of its parent,
+// a queue does not deserve more than its parent if the deserved resources of the parent are set.
+func (cp *capacityPlugin) updateDeserved(ssn *framework.Session, attr *queueAttr, updated map[api.QueueID]bool) {
+	if updated[attr.queueID] {
+		return
+	}
+	updated[attr.queueID] = true
+
+	cp.updateRealCapability(attr)
+	attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
+	if attr.parent != nil && len(ssn.Queues[attr.parent.queueID].Queue.Spec.Deserved) != 0 {
+		cp.updateDeserved(ssn, attr.parent, updated)
+		attr.deserved.MinDimensionResource(attr.parent.deserved, api.Infinity)
+	}
+	// When scalar resource not specified in deserved such as "pods", we should skip it and consider deserved resource as infinity.
+	attr.deserved.MinDimensionResource(attr.request, api.Infinity)
+
+	attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
+	cp.updateShare(attr)
+	klog.V(4).Infof("The attributes of queue <%s> in capacity: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
+		attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
+}
+
+func compareShare(l, r *queueAttr) int {
+	if l.share == r.share {
+		return 0
+	}
+
+	if l.share < r.share {
+		return -1
+	}
+
+	return 1
+}
+
+// overusedQueue returns the first queue of the path whose allocated resources reached the deserved ones.
+func overusedQueue(path []*queueAttr) *queueAttr {
+	for _, attr := range path {
+		if attr.deserved.LessEqual(attr.allocated, api.Zero) {
+			return attr
+		}
+	}
+	return nil
+}
+
+// reclaimableFromQueues returns whether the reclaimee can be reclaimed from all the queues of the
+// path, i.e. they are above their deserved resources and keep their guarantee without the reclaimee.
+func reclaimableFromQueues(reclaimer, reclaimee *api.TaskInfo, path []*queueAttr, allocations map[api.QueueID]*api.Resource) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	for _, attr := range path {
+		if _, found := allocations[attr.queueID]; !found {
+			allocations[attr.queueID] = attr.allocated.Clone()
+		}
+		allocated := allocations[attr.queueID]
+		if allocated.LessPartly(reclaimer.Resreq, api.Zero) {
+			klog.V(3).Infof("Failed to allocate resource for Task <%s/%s> in Queue <%s>, not enough resource.",
+				reclaimee.Namespace, reclaimee.Name, attr.name)
+			return false
+		}
+
+		exceptReclaimee := allocated.Clone().Sub(reclaimee.Resreq)
+		// When scalar resource not specified in deserved such as "pods", we should skip it and consider it as infinity,
+		// so the following first condition will be true and the current queue will not be reclaimed.
+		if allocated.LessEqual(attr.deserved, api.Infinity) || !attr.guarantee.LessEqual(exceptReclaimee, api.Zero) {
+			return false
+		}
+	}
+	return true
+}
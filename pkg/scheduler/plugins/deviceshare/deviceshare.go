@@ -54,6 +54,16 @@ type deviceSharePlugin struct {
 	scheduleWeight  int
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	GPUSharingPredicate:    framework.BoolArgument,
+	GPUNumberPredicate:     framework.BoolArgument,
+	NodeLockEnable:         framework.BoolArgument,
+	VGPUEnable:             framework.BoolArgument,
+	SchedulePolicyArgument: framework.StringArgument,
+	ScheduleWeight:         framework.IntArgument,
+}
+
 // New return priority plugin
 func New(arguments framework.Arguments) framework.Plugin {
 	dsp := &deviceSharePlugin{pluginArguments: arguments, schedulePolicy: "", scheduleWeight: 0}
@@ -30,6 +30,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util/fairshare"
 )
 
 // PluginName indicates name of volcano scheduler plugin.
@@ -113,10 +114,17 @@ type drfPlugin struct {
 	// hierarchical tree root
 	hierarchicalRoot *hierarchicalNode
 
+	// fairShare is nil unless the jobs and queues are ordered by the decayed usage of the queues too
+	fairShare *fairshare.Config
+	history   *fairshare.History
+
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = fairshare.ArgumentSchema
+
 // New return drf plugin
 func New(arguments framework.Arguments) framework.Plugin {
 	return &drfPlugin{
@@ -131,6 +139,7 @@ func New(arguments framework.Arguments) framework.Plugin {
 			weight:    1,
 			children:  map[string]*hierarchicalNode{},
 		},
+		fairShare:       fairshare.NewConfig(arguments),
 		pluginArguments: arguments,
 	}
 }
@@ -192,6 +201,11 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 
 	hierarchyEnabled := drf.HierarchyEnabled(ssn)
 
+	if drf.fairShare != nil {
+		drf.history = fairshare.GetHistory(drf.fairShare)
+		drf.history.Update(ssn, drf.fairShare)
+	}
+
 	for _, job := range ssn.Jobs {
 		attr := &drfAttr{
 			allocated: api.EmptyResource(),
@@ -322,20 +336,35 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 			return victims, util.Permit
 		}
 		ssn.AddReclaimableFn(drf.Name(), reclaimFn)
+	} else if drf.history != nil {
+		queueOrderFn := func(l interface{}, r interface{}) int {
+			lv := l.(*api.QueueInfo)
+			rv := r.(*api.QueueInfo)
+			lusage, rusage := drf.history.Share(lv.UID), drf.history.Share(rv.UID)
+			if lusage == rusage {
+				return 0
+			}
+			if lusage < rusage {
+				return -1
+			}
+			return 1
+		}
+		ssn.AddQueueOrderFn(drf.Name(), queueOrderFn)
 	}
 
 	jobOrderFn := func(l interface{}, r interface{}) int {
 		lv := l.(*api.JobInfo)
 		rv := r.(*api.JobInfo)
 
+		lshare, rshare := drf.orderShare(lv), drf.orderShare(rv)
 		klog.V(4).Infof("DRF JobOrderFn: <%v/%v> share state: %v, <%v/%v> share state: %v",
-			lv.Namespace, lv.Name, drf.jobAttrs[lv.UID].share, rv.Namespace, rv.Name, drf.jobAttrs[rv.UID].share)
+			lv.Namespace, lv.Name, lshare, rv.Namespace, rv.Name, rshare)
 
-		if drf.jobAttrs[lv.UID].share == drf.jobAttrs[rv.UID].share {
+		if lshare == rshare {
 			return 0
 		}
 
-		if drf.jobAttrs[lv.UID].share < drf.jobAttrs[rv.UID].share {
+		if lshare < rshare {
 			return -1
 		}
 
@@ -499,6 +528,16 @@ func (drf *drfPlugin) updateShare(attr *drfAttr) {
 	attr.dominantResource, attr.share = drf.calculateShare(attr.allocated, drf.totalResource)
 }
 
+// orderShare returns the share the jobs are ordered by: the dominant share of the job, plus the
+// decayed usage of its queue if fair share is enabled.
+func (drf *drfPlugin) orderShare(job *api.JobInfo) float64 {
+	share := drf.jobAttrs[job.UID].share
+	if drf.history != nil {
+		share += drf.fairShare.Weight * drf.history.Share(job.Queue)
+	}
+	return share
+}
+
 func (drf *drfPlugin) calculateShare(allocated, totalResource *api.Resource) (string, float64) {
 	res := float64(0)
 	dominantResource := ""
@@ -518,4 +557,5 @@ func (drf *drfPlugin) OnSessionClose(session *framework.Session) {
 	drf.totalResource = api.EmptyResource()
 	drf.totalAllocated = api.EmptyResource()
 	drf.jobAttrs = map[api.JobID]*drfAttr{}
+	drf.history = nil
 }
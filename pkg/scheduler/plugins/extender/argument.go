@@ -25,6 +25,22 @@ type PredicateResponse struct {
 	Status []*api.Status `json:"status"`
 }
 
+// BatchPredicateRequest carries the names of the nodes instead of the nodes if the extender is node cache capable.
+type BatchPredicateRequest struct {
+	Task      *api.TaskInfo   `json:"task"`
+	Nodes     []*api.NodeInfo `json:"nodes,omitempty"`
+	NodeNames []string        `json:"nodeNames,omitempty"`
+}
+
+// BatchPredicateResponse carries the nodes the task fits on, the reasons it does not
+// fit on the other nodes and optionally the scores of the nodes.
+type BatchPredicateResponse struct {
+	NodeNames    []string           `json:"nodeNames"`
+	FailedNodes  map[string]string  `json:"failedNodes"`
+	NodeScore    map[string]float64 `json:"nodeScore"`
+	ErrorMessage string             `json:"errorMessage"`
+}
+
 type PrioritizeRequest struct {
 	Task  *api.TaskInfo   `json:"task"`
 	Nodes []*api.NodeInfo `json:"nodes"`
@@ -70,3 +86,41 @@ type JobReadyRequest struct {
 type JobReadyResponse struct {
 	Status bool `json:"status"`
 }
+
+// OrderResponse carries the UIDs of the objects of an order request from the first to the last one,
+// the extender abstains from ordering the objects it does not return.
+type OrderResponse struct {
+	Order []string `json:"order"`
+}
+
+// JobOrderRequest carries the jobs of the session, they are ordered once per session.
+type JobOrderRequest struct {
+	Jobs []*api.JobInfo `json:"jobs"`
+}
+
+// TaskOrderRequest carries the tasks of the jobs of the session, they are ordered once per session.
+type TaskOrderRequest struct {
+	Tasks []*api.TaskInfo `json:"tasks"`
+}
+
+// QueueOrderRequest carries the queues of the session, they are ordered once per session.
+type QueueOrderRequest struct {
+	Queues []*api.QueueInfo `json:"queues"`
+}
+
+type VictimTasksRequest struct {
+	Tasks []*api.TaskInfo `json:"tasks"`
+}
+
+type VictimTasksResponse struct {
+	Victims []*api.TaskInfo `json:"victims"`
+}
+
+type AllocatableRequest struct {
+	Queue *api.QueueInfo `json:"queue"`
+	Task  *api.TaskInfo  `json:"task"`
+}
+
+type AllocatableResponse struct {
+	Status bool `json:"status"`
+}
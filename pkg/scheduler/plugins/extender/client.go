@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The plugin is built for every session, the connections and the state of the
+// circuit breakers are kept across sessions for each extender.
+var (
+	clientMutex sync.Mutex
+	transports  = map[string]*http.Transport{}
+	breakers    = map[string]*circuitBreaker{}
+)
+
+// transport returns the connection pool to the extender, it is sized by the
+// first configuration of the extender.
+func transport(urlPrefix string, maxConnections int) *http.Transport {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	if t, found := transports[urlPrefix]; found {
+		return t
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = maxConnections
+	t.MaxIdleConnsPerHost = maxConnections
+	t.MaxConnsPerHost = maxConnections
+	transports[urlPrefix] = t
+	return t
+}
+
+// breaker returns the circuit breaker of the extender, nil if it is disabled.
+func breaker(urlPrefix string, threshold int, openDuration time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	cb, found := breakers[urlPrefix]
+	if !found {
+		cb = &circuitBreaker{}
+		breakers[urlPrefix] = cb
+	}
+	cb.mutex.Lock()
+	cb.threshold = threshold
+	cb.openDuration = openDuration
+	cb.mutex.Unlock()
+	return cb
+}
+
+// circuitBreaker stops calling an extender after threshold consecutive failures.
+// Once openDuration elapsed, a single call is let through: the breaker is
+// closed again if it succeeds and opened for another openDuration otherwise.
+type circuitBreaker struct {
+	mutex        sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	failures     int
+	openUntil    time.Time
+	probing      bool
+}
+
+// allow returns whether the extender may be called.
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.failures < cb.threshold {
+		return true
+	}
+	if cb.probing || time.Now().Before(cb.openUntil) {
+		return false
+	}
+	cb.probing = true
+	return true
+}
+
+// record records the outcome of a call allowed by the breaker.
+func (cb *circuitBreaker) record(succeeded bool) {
+	if cb == nil {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.probing = false
+	if succeeded {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openUntil = time.Now().Add(cb.openDuration)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -46,6 +47,12 @@ const (
 	ExtenderOnSessionCloseVerb = "extender.onSessionCloseVerb"
 	// ExtenderPredicateVerb is the verb of Predicate method
 	ExtenderPredicateVerb = "extender.predicateVerb"
+	// ExtenderBatchPredicateVerb is the verb of the Predicate method filtering all nodes at once,
+	// it takes precedence over ExtenderPredicateVerb, which is ignored if both are set
+	ExtenderBatchPredicateVerb = "extender.batchPredicateVerb"
+	// ExtenderNodeCacheCapable indicates whether the extender caches the nodes sent by OnSessionOpen,
+	// only the node names are sent to the batch predicate verb if so
+	ExtenderNodeCacheCapable = "extender.nodeCacheCapable"
 	// ExtenderPrioritizeVerb is the verb of Prioritize method
 	ExtenderPrioritizeVerb = "extender.prioritizeVerb"
 	// ExtenderPreemptableVerb is the verb of Preemptable method
@@ -58,8 +65,31 @@ const (
 	ExtenderJobEnqueueableVerb = "extender.jobEnqueueableVerb"
 	// ExtenderJobReadyVerb is the verb of JobReady method
 	ExtenderJobReadyVerb = "extender.jobReadyVerb"
+	// ExtenderJobOrderVerb is the verb of JobOrder method
+	ExtenderJobOrderVerb = "extender.jobOrderVerb"
+	// ExtenderTaskOrderVerb is the verb of TaskOrder method
+	ExtenderTaskOrderVerb = "extender.taskOrderVerb"
+	// ExtenderQueueOrderVerb is the verb of QueueOrder method
+	ExtenderQueueOrderVerb = "extender.queueOrderVerb"
+	// ExtenderVictimTasksVerb is the verb of VictimTasks method
+	ExtenderVictimTasksVerb = "extender.victimTasksVerb"
+	// ExtenderAllocatableVerb is the verb of Allocatable method
+	ExtenderAllocatableVerb = "extender.allocatableVerb"
 	// ExtenderIgnorable indicates whether the extender can ignore unexpected errors
 	ExtenderIgnorable = "extender.ignorable"
+	// ExtenderMaxConnections is the maximum number of connections to the extender
+	ExtenderMaxConnections = "extender.maxConnections"
+	// ExtenderRetries is the number of times the failed calls to the extender are retried
+	ExtenderRetries = "extender.retries"
+	// ExtenderCircuitBreakerThreshold is the number of consecutive failures after which the extender
+	// is not called anymore, it is only enabled if the extender is ignorable
+	ExtenderCircuitBreakerThreshold = "extender.circuitBreakerThreshold"
+	// ExtenderCircuitBreakerDuration is the duration the extender is not called for once the threshold is reached
+	ExtenderCircuitBreakerDuration = "extender.circuitBreakerDuration"
+
+	defaultMaxConnections         = 16
+	defaultCircuitBreakerDuration = 30 * time.Second
+	retryBackoff                  = 10 * time.Millisecond
 )
 
 type extenderConfig struct {
@@ -68,18 +98,48 @@ type extenderConfig struct {
 	onSessionOpenVerb  string
 	onSessionCloseVerb string
 	predicateVerb      string
+	batchPredicateVerb string
+	nodeCacheCapable   bool
 	prioritizeVerb     string
 	preemptableVerb    string
 	reclaimableVerb    string
 	queueOverusedVerb  string
 	jobEnqueueableVerb string
 	jobReadyVerb       string
+	jobOrderVerb       string
+	taskOrderVerb      string
+	queueOrderVerb     string
+	victimTasksVerb    string
+	allocatableVerb    string
 	ignorable          bool
+	maxConnections     int
+	retries            int
+	breakerThreshold   int
+	breakerDuration    time.Duration
 }
 
 type extenderPlugin struct {
-	client http.Client
-	config *extenderConfig
+	client  http.Client
+	config  *extenderConfig
+	breaker *circuitBreaker
+
+	// batchPredicates caches the response of the batch predicate verb per task,
+	// it is refreshed by the PrePredicateFn called before the nodes are filtered.
+	batchMutex      sync.Mutex
+	batchPredicates map[api.TaskID]*batchPredicateResult
+
+	// The orders of the jobs, tasks and queues are fetched once per session by the first comparison.
+	jobOrder   ranking
+	taskOrder  ranking
+	queueOrder ranking
+}
+
+type batchPredicateResult struct {
+	once   sync.Once
+	nodes  map[string]bool
+	failed map[string]string
+	scores map[string]float64
+	err    error
 }
 
 func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
@@ -99,13 +159,23 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 				   extender.httpTimeout: 100ms
 				   extender.onSessionOpenVerb: onSessionOpen
 				   extender.onSessionCloseVerb: onSessionClose
-				   extender.predicateVerb: predicate
+				   extender.batchPredicateVerb: batchPredicate
+				   extender.nodeCacheCapable: true
 				   extender.prioritizeVerb: prioritize
 				   extender.preemptableVerb: preemptable
 				   extender.reclaimableVerb: reclaimable
 				   extender.queueOverusedVerb: queueOverused
 				   extender.jobEnqueueableVerb: jobEnqueueable
+				   extender.jobOrderVerb: jobOrder
+				   extender.taskOrderVerb: taskOrder
+				   extender.queueOrderVerb: queueOrder
+				   extender.victimTasksVerb: victimTasks
+				   extender.allocatableVerb: allocatable
 				   extender.ignorable: true
+				   extender.maxConnections: 16
+				   extender.retries: 2
+				   extender.circuitBreakerThreshold: 5
+				   extender.circuitBreakerDuration: 30s
 		     - name: proportion
 		     - name: nodeorder
 	*/
@@ -114,14 +184,31 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 	ec.onSessionOpenVerb, _ = arguments[ExtenderOnSessionOpenVerb].(string)
 	ec.onSessionCloseVerb, _ = arguments[ExtenderOnSessionCloseVerb].(string)
 	ec.predicateVerb, _ = arguments[ExtenderPredicateVerb].(string)
+	ec.batchPredicateVerb, _ = arguments[ExtenderBatchPredicateVerb].(string)
+	if ec.predicateVerb != "" && ec.batchPredicateVerb != "" {
+		klog.Warningf("Both %s and %s are set, only the batch predicate verb %s is used.",
+			ExtenderPredicateVerb, ExtenderBatchPredicateVerb, ec.batchPredicateVerb)
+		ec.predicateVerb = ""
+	}
 	ec.prioritizeVerb, _ = arguments[ExtenderPrioritizeVerb].(string)
 	ec.preemptableVerb, _ = arguments[ExtenderPreemptableVerb].(string)
 	ec.reclaimableVerb, _ = arguments[ExtenderReclaimableVerb].(string)
 	ec.queueOverusedVerb, _ = arguments[ExtenderQueueOverusedVerb].(string)
 	ec.jobEnqueueableVerb, _ = arguments[ExtenderJobEnqueueableVerb].(string)
 	ec.jobReadyVerb, _ = arguments[ExtenderJobReadyVerb].(string)
+	ec.jobOrderVerb, _ = arguments[ExtenderJobOrderVerb].(string)
+	ec.taskOrderVerb, _ = arguments[ExtenderTaskOrderVerb].(string)
+	ec.queueOrderVerb, _ = arguments[ExtenderQueueOrderVerb].(string)
+	ec.victimTasksVerb, _ = arguments[ExtenderVictimTasksVerb].(string)
+	ec.allocatableVerb, _ = arguments[ExtenderAllocatableVerb].(string)
 
 	arguments.GetBool(&ec.ignorable, ExtenderIgnorable)
+	arguments.GetBool(&ec.nodeCacheCapable, ExtenderNodeCacheCapable)
+
+	ec.maxConnections = defaultMaxConnections
+	arguments.GetInt(&ec.maxConnections, ExtenderMaxConnections)
+	arguments.GetInt(&ec.retries, ExtenderRetries)
+	arguments.GetInt(&ec.breakerThreshold, ExtenderCircuitBreakerThreshold)
 
 	ec.httpTimeout = time.Second
 	if httpTimeout, _ := arguments[ExtenderHTTPTimeout].(string); httpTimeout != "" {
@@ -129,14 +216,59 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 			ec.httpTimeout = timeoutDuration
 		}
 	}
+	ec.breakerDuration = defaultCircuitBreakerDuration
+	if breakerDuration, _ := arguments[ExtenderCircuitBreakerDuration].(string); breakerDuration != "" {
+		if duration, err := time.ParseDuration(breakerDuration); err == nil {
+			ec.breakerDuration = duration
+		}
+	}
 
 	return ec
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	ExtenderURLPrefix:               framework.StringArgument,
+	ExtenderHTTPTimeout:             framework.DurationArgument,
+	ExtenderOnSessionOpenVerb:       framework.StringArgument,
+	ExtenderOnSessionCloseVerb:      framework.StringArgument,
+	ExtenderPredicateVerb:           framework.StringArgument,
+	ExtenderBatchPredicateVerb:      framework.StringArgument,
+	ExtenderNodeCacheCapable:        framework.BoolArgument,
+	ExtenderPrioritizeVerb:          framework.StringArgument,
+	ExtenderPreemptableVerb:         framework.StringArgument,
+	ExtenderReclaimableVerb:         framework.StringArgument,
+	ExtenderQueueOverusedVerb:       framework.StringArgument,
+	ExtenderJobEnqueueableVerb:      framework.StringArgument,
+	ExtenderJobReadyVerb:            framework.StringArgument,
+	ExtenderJobOrderVerb:            framework.StringArgument,
+	ExtenderTaskOrderVerb:           framework.StringArgument,
+	ExtenderQueueOrderVerb:          framework.StringArgument,
+	ExtenderVictimTasksVerb:         framework.StringArgument,
+	ExtenderAllocatableVerb:         framework.StringArgument,
+	ExtenderIgnorable:               framework.BoolArgument,
+	ExtenderMaxConnections:          framework.IntArgument,
+	ExtenderRetries:                 framework.IntArgument,
+	ExtenderCircuitBreakerThreshold: framework.IntArgument,
+	ExtenderCircuitBreakerDuration:  framework.DurationArgument,
+}
+
 func New(arguments framework.Arguments) framework.Plugin {
 	cfg := parseExtenderConfig(arguments)
 	klog.V(4).Infof("Initialize extender plugin with endpoint address %s", cfg.urlPrefix)
-	return &extenderPlugin{client: http.Client{Timeout: cfg.httpTimeout}, config: cfg}
+	ep := &extenderPlugin{
+		client: http.Client{
+			Timeout:   cfg.httpTimeout,
+			Transport: transport(cfg.urlPrefix, cfg.maxConnections),
+		},
+		config:          cfg,
+		batchPredicates: map[api.TaskID]*batchPredicateResult{},
+	}
+	// Calls are only skipped by the circuit breaker if the extender may be ignored.
+	if cfg.ignorable {
+		ep.breaker = breaker(cfg.urlPrefix, cfg.breakerThreshold, cfg.breakerDuration)
+	}
+	return ep
 }
 
 func (ep *extenderPlugin) Name() string {
@@ -178,6 +310,51 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		})
 	}
 
+	if ep.config.batchPredicateVerb != "" {
+		ssn.AddPrePredicateFn(ep.Name(), func(task *api.TaskInfo) error {
+			result := ep.batchPredicate(ssn, task, true)
+			if result.err != nil && !ep.config.ignorable {
+				return result.err
+			}
+			return nil
+		})
+
+		ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
+			result := ep.batchPredicate(ssn, task, false)
+			if result.err != nil {
+				if ep.config.ignorable {
+					return nil, nil
+				}
+				return nil, result.err
+			}
+
+			if !result.nodes[node.Name] {
+				reason := result.failed[node.Name]
+				if reason == "" {
+					reason = "node is filtered by extender"
+				}
+				status := &api.Status{Code: api.Unschedulable, Reason: reason}
+				return []*api.Status{status}, fmt.Errorf("plugin %s predicates failed %s", ep.Name(), reason)
+			}
+			return []*api.Status{{Code: api.Success}}, nil
+		})
+
+		// The scores returned along with the filtered nodes are used if there is no prioritize verb.
+		if ep.config.prioritizeVerb == "" {
+			ssn.AddBatchNodeOrderFn(ep.Name(), func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
+				result := ep.batchPredicate(ssn, task, false)
+				if result.err != nil || result.scores == nil {
+					return nil, nil
+				}
+				scores := make(map[string]float64, len(nodes))
+				for _, node := range nodes {
+					scores[node.Name] = result.scores[node.Name]
+				}
+				return scores, nil
+			})
+		}
+	}
+
 	if ep.config.prioritizeVerb != "" {
 		ssn.AddBatchNodeOrderFn(ep.Name(), func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
 			resp := &PrioritizeResponse{}
@@ -279,6 +456,82 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 			return resp.Status
 		})
 	}
+
+	if ep.config.jobOrderVerb != "" {
+		ssn.AddJobOrderFn(ep.Name(), func(l, r interface{}) int {
+			return ep.jobOrder.compare(string(l.(*api.JobInfo).UID), string(r.(*api.JobInfo).UID), func() ([]string, error) {
+				req := &JobOrderRequest{Jobs: make([]*api.JobInfo, 0, len(ssn.Jobs))}
+				for _, job := range ssn.Jobs {
+					req.Jobs = append(req.Jobs, job)
+				}
+				return ep.fetchOrder(ep.config.jobOrderVerb, req)
+			})
+		})
+	}
+
+	if ep.config.taskOrderVerb != "" {
+		ssn.AddTaskOrderFn(ep.Name(), func(l, r interface{}) int {
+			return ep.taskOrder.compare(string(l.(*api.TaskInfo).UID), string(r.(*api.TaskInfo).UID), func() ([]string, error) {
+				req := &TaskOrderRequest{}
+				for _, job := range ssn.Jobs {
+					for _, task := range job.Tasks {
+						req.Tasks = append(req.Tasks, task)
+					}
+				}
+				return ep.fetchOrder(ep.config.taskOrderVerb, req)
+			})
+		})
+	}
+
+	if ep.config.queueOrderVerb != "" {
+		ssn.AddQueueOrderFn(ep.Name(), func(l, r interface{}) int {
+			return ep.queueOrder.compare(string(l.(*api.QueueInfo).UID), string(r.(*api.QueueInfo).UID), func() ([]string, error) {
+				req := &QueueOrderRequest{Queues: make([]*api.QueueInfo, 0, len(ssn.Queues))}
+				for _, queue := range ssn.Queues {
+					req.Queues = append(req.Queues, queue)
+				}
+				return ep.fetchOrder(ep.config.queueOrderVerb, req)
+			})
+		})
+	}
+
+	if ep.config.victimTasksVerb != "" {
+		ssn.AddVictimTasksFns(ep.Name(), []api.VictimTasksFn{func(tasks []*api.TaskInfo) []*api.TaskInfo {
+			resp := &VictimTasksResponse{}
+			err := ep.send(ep.config.victimTasksVerb, &VictimTasksRequest{Tasks: tasks}, resp)
+			if err != nil {
+				klog.Warningf("VictimTasks failed with error %v", err)
+				return nil
+			}
+
+			// The victims are decoded from the response, return the tasks of the session instead.
+			victims := make(map[api.TaskID]bool, len(resp.Victims))
+			for _, victim := range resp.Victims {
+				victims[victim.UID] = true
+			}
+			var result []*api.TaskInfo
+			for _, task := range tasks {
+				if victims[task.UID] {
+					result = append(result, task)
+				}
+			}
+			return result
+		}})
+	}
+
+	if ep.config.allocatableVerb != "" {
+		ssn.AddAllocatableFn(ep.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
+			resp := &AllocatableResponse{}
+			err := ep.send(ep.config.allocatableVerb, &AllocatableRequest{Queue: queue, Task: candidate}, resp)
+			if err != nil {
+				klog.Warningf("Allocatable failed with error %v", err)
+
+				return ep.config.ignorable
+			}
+
+			return resp.Status
+		})
+	}
 }
 
 func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
@@ -289,6 +542,94 @@ func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
 	}
 }
 
+// ranking is the order of the objects of the session returned by an order verb.
+type ranking struct {
+	once  sync.Once
+	ranks map[string]int
+}
+
+// compare orders the objects by their ranks, which are fetched by the first comparison of the session.
+// The extender abstains from ordering the objects if one of them is not ranked, or if the order could not be fetched.
+func (rk *ranking) compare(l, r string, fetch func() ([]string, error)) int {
+	rk.once.Do(func() {
+		order, err := fetch()
+		if err != nil {
+			return
+		}
+		rk.ranks = make(map[string]int, len(order))
+		for rank, uid := range order {
+			if _, found := rk.ranks[uid]; !found {
+				rk.ranks[uid] = rank
+			}
+		}
+	})
+
+	lRank, lFound := rk.ranks[l]
+	rRank, rFound := rk.ranks[r]
+	if !lFound || !rFound || lRank == rRank {
+		return 0
+	}
+	if lRank < rRank {
+		return -1
+	}
+	return 1
+}
+
+// fetchOrder returns the UIDs of the objects of the request in the order returned by the order verb.
+func (ep *extenderPlugin) fetchOrder(verb string, req interface{}) ([]string, error) {
+	resp := &OrderResponse{}
+	if err := ep.send(verb, req, resp); err != nil {
+		klog.Warningf("Order verb %s failed with error %v, the extender abstains from ordering in this session", verb, err)
+		return nil, err
+	}
+	return resp.Order, nil
+}
+
+// batchPredicate returns the nodes the task fits on, they are fetched once for all
+// nodes of the session unless refresh is set.
+func (ep *extenderPlugin) batchPredicate(ssn *framework.Session, task *api.TaskInfo, refresh bool) *batchPredicateResult {
+	ep.batchMutex.Lock()
+	result, found := ep.batchPredicates[task.UID]
+	if !found || refresh {
+		result = &batchPredicateResult{}
+		ep.batchPredicates[task.UID] = result
+	}
+	ep.batchMutex.Unlock()
+
+	result.once.Do(func() {
+		req := &BatchPredicateRequest{Task: task}
+		if ep.config.nodeCacheCapable {
+			req.NodeNames = make([]string, 0, len(ssn.NodeList))
+			for _, node := range ssn.NodeList {
+				req.NodeNames = append(req.NodeNames, node.Name)
+			}
+		} else {
+			req.Nodes = ssn.NodeList
+		}
+
+		resp := &BatchPredicateResponse{}
+		if err := ep.send(ep.config.batchPredicateVerb, req, resp); err != nil {
+			klog.Warningf("BatchPredicate failed with error %v", err)
+			result.err = err
+			return
+		}
+		if resp.ErrorMessage != "" {
+			result.err = errors.New(resp.ErrorMessage)
+			return
+		}
+
+		result.nodes = make(map[string]bool, len(resp.NodeNames))
+		for _, name := range resp.NodeNames {
+			result.nodes[name] = true
+		}
+		result.failed = resp.FailedNodes
+		result.scores = resp.NodeScore
+	})
+	return result
+}
+
+// send calls the verb of the extender, the calls failing with a network error or
+// a server error are retried.
 func (ep *extenderPlugin) send(action string, args interface{}, result interface{}) error {
 	out, err := json.Marshal(args)
 	if err != nil {
@@ -296,26 +637,49 @@ func (ep *extenderPlugin) send(action string, args interface{}, result interface
 	}
 
 	url := strings.TrimRight(ep.config.urlPrefix, "/") + "/" + action
+	if !ep.breaker.allow() {
+		return fmt.Errorf("failed %v with extender at URL %v, circuit breaker is open", action, url)
+	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader(out))
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		var retriable bool
+		retriable, err = ep.post(url, out, result)
+		if err == nil || !retriable || attempt >= ep.config.retries {
+			break
+		}
+		klog.V(4).Infof("Retrying %v with extender at URL %v after error %v", action, url, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	ep.breaker.record(err == nil)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed %v with extender at URL %v: %v", action, url, err)
+	}
+	return nil
+}
+
+// post posts the request to the url, it returns whether the call may be retried if it failed.
+func (ep *extenderPlugin) post(url string, body []byte, result interface{}) (bool, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := ep.client.Do(req)
 	if err != nil {
-		return err
+		return true, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed %v with extender at URL %v, code %v", action, url, resp.StatusCode)
+		return resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("code %v", resp.StatusCode)
 	}
 
 	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
+		return false, json.NewDecoder(resp.Body).Decode(result)
 	}
-	return nil
+	return false, nil
 }
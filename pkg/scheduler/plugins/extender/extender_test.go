@@ -0,0 +1,265 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func openSession(t *testing.T, arguments framework.Arguments) *framework.Session {
+	framework.RegisterPluginBuilder(PluginName, New)
+	t.Cleanup(framework.CleanupPluginBuilders)
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddQueueV1beta1(util.BuildQueue("q2", 1, nil))
+	for _, pg := range []string{"pg1", "pg2"} {
+		sc.AddPodGroupV1beta1(util.BuildPodGroup(pg, "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+		sc.AddPod(util.BuildPod("ns1", "p-"+pg, "", v1.PodPending, api.BuildResourceList("1", "1G"), pg, make(map[string]string), make(map[string]string)))
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledPredicate:   &trueValue,
+					EnabledNodeOrder:   &trueValue,
+					EnabledJobEnqueued: &trueValue,
+					EnabledJobOrder:    &trueValue,
+					EnabledTaskOrder:   &trueValue,
+					EnabledQueueOrder:  &trueValue,
+					EnabledVictim:      &trueValue,
+					EnabledAllocatable: &trueValue,
+					Arguments:          arguments,
+				},
+			},
+		},
+	}
+	ssn := framework.OpenSession(sc, tiers, nil)
+	t.Cleanup(func() { framework.CloseSession(ssn) })
+	return ssn
+}
+
+func jobTask(ssn *framework.Session, job string) *api.TaskInfo {
+	for _, task := range ssn.Jobs[api.JobID("ns1/"+job)].Tasks {
+		return task
+	}
+	return nil
+}
+
+func TestBatchPredicate(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		req := &BatchPredicateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(req.Nodes) != 0 || len(req.NodeNames) != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(&BatchPredicateResponse{
+			NodeNames:   []string{"n1"},
+			FailedNodes: map[string]string{"n2": "n2 is reserved"},
+			NodeScore:   map[string]float64{"n1": 10},
+		})
+	}))
+	defer server.Close()
+
+	ssn := openSession(t, framework.Arguments{
+		ExtenderURLPrefix:          server.URL,
+		ExtenderBatchPredicateVerb: "batchPredicate",
+		ExtenderNodeCacheCapable:   true,
+	})
+	task := jobTask(ssn, "pg1")
+
+	if err := ssn.PrePredicateFn(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ssn.PredicateFn(task, ssn.Nodes["n1"]); err != nil {
+		t.Errorf("expected task to fit on n1, got error %v", err)
+	}
+	status, err := ssn.PredicateFn(task, ssn.Nodes["n2"])
+	if err == nil || len(status) != 1 || status[0].Reason != "n2 is reserved" {
+		t.Errorf("expected task not to fit on n2 as n2 is reserved, got %v, %v", status, err)
+	}
+	scores, err := ssn.BatchNodeOrderFn(task, []*api.NodeInfo{ssn.Nodes["n1"]})
+	if err != nil || scores["n1"] != 10 {
+		t.Errorf("expected score 10 for n1, got %v, %v", scores, err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected the extender to be called once, called %d times", calls)
+	}
+}
+
+func TestPredicateVerbPrecedence(t *testing.T) {
+	config := parseExtenderConfig(framework.Arguments{
+		ExtenderPredicateVerb:      "predicate",
+		ExtenderBatchPredicateVerb: "batchPredicate",
+	})
+	if config.predicateVerb != "" || config.batchPredicateVerb != "batchPredicate" {
+		t.Errorf("expected only the batch predicate verb to be used, got predicate verb %q and batch predicate verb %q",
+			config.predicateVerb, config.batchPredicateVerb)
+	}
+}
+
+func TestRetriesAndCircuitBreaker(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ssn := openSession(t, framework.Arguments{
+		ExtenderURLPrefix:               server.URL,
+		ExtenderJobEnqueueableVerb:      "jobEnqueueable",
+		ExtenderIgnorable:               true,
+		ExtenderRetries:                 1,
+		ExtenderCircuitBreakerThreshold: 2,
+		ExtenderCircuitBreakerDuration:  "1h",
+	})
+	job := ssn.Jobs[api.JobID("ns1/pg1")]
+
+	for i := 0; i < 3; i++ {
+		if !ssn.JobEnqueueable(job) {
+			t.Errorf("expected job to be enqueueable as the extender is ignorable")
+		}
+	}
+	// Two calls retried once each open the circuit breaker, the third is not sent.
+	if calls := atomic.LoadInt32(&calls); calls != 4 {
+		t.Errorf("expected the extender to be called 4 times, called %d times", calls)
+	}
+}
+
+func TestOrderVictimAndAllocatableVerbs(t *testing.T) {
+	// The extender orders the objects by name in reverse order, selects the
+	// tasks of pg2 as victims and lets the tasks of pg1 be allocated only.
+	var orderCalls int32
+	reverse := func(names map[string]string) *OrderResponse {
+		atomic.AddInt32(&orderCalls, 1)
+		resp := &OrderResponse{}
+		for uid := range names {
+			resp.Order = append(resp.Order, uid)
+		}
+		sort.Slice(resp.Order, func(i, j int) bool {
+			return names[resp.Order[i]] > names[resp.Order[j]]
+		})
+		return resp
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobOrder", func(w http.ResponseWriter, r *http.Request) {
+		req := &JobOrderRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		names := map[string]string{}
+		for _, job := range req.Jobs {
+			names[string(job.UID)] = job.Name
+		}
+		json.NewEncoder(w).Encode(reverse(names))
+	})
+	mux.HandleFunc("/taskOrder", func(w http.ResponseWriter, r *http.Request) {
+		req := &TaskOrderRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		names := map[string]string{}
+		for _, task := range req.Tasks {
+			names[string(task.UID)] = task.Name
+		}
+		json.NewEncoder(w).Encode(reverse(names))
+	})
+	mux.HandleFunc("/queueOrder", func(w http.ResponseWriter, r *http.Request) {
+		req := &QueueOrderRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		names := map[string]string{}
+		for _, queue := range req.Queues {
+			names[string(queue.UID)] = queue.Name
+		}
+		json.NewEncoder(w).Encode(reverse(names))
+	})
+	mux.HandleFunc("/victimTasks", func(w http.ResponseWriter, r *http.Request) {
+		req := &VictimTasksRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		resp := &VictimTasksResponse{}
+		for _, task := range req.Tasks {
+			if task.Name == "p-pg2" {
+				resp.Victims = append(resp.Victims, task)
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("/allocatable", func(w http.ResponseWriter, r *http.Request) {
+		req := &AllocatableRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		json.NewEncoder(w).Encode(&AllocatableResponse{Status: req.Task.Name == "p-pg1"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ssn := openSession(t, framework.Arguments{
+		ExtenderURLPrefix:       server.URL,
+		ExtenderJobOrderVerb:    "jobOrder",
+		ExtenderTaskOrderVerb:   "taskOrder",
+		ExtenderQueueOrderVerb:  "queueOrder",
+		ExtenderVictimTasksVerb: "victimTasks",
+		ExtenderAllocatableVerb: "allocatable",
+	})
+	pg1, pg2 := ssn.Jobs[api.JobID("ns1/pg1")], ssn.Jobs[api.JobID("ns1/pg2")]
+	task1, task2 := jobTask(ssn, "pg1"), jobTask(ssn, "pg2")
+	queue := ssn.Queues[api.QueueID("q1")]
+
+	if !ssn.JobOrderFn(pg2, pg1) || ssn.JobOrderFn(pg1, pg2) {
+		t.Errorf("expected job pg2 to be ordered before job pg1")
+	}
+	if !ssn.TaskOrderFn(task2, task1) || ssn.TaskOrderFn(task1, task2) {
+		t.Errorf("expected task p-pg2 to be ordered before task p-pg1")
+	}
+	if !ssn.QueueOrderFn(ssn.Queues[api.QueueID("q2")], queue) {
+		t.Errorf("expected queue q2 to be ordered before queue q1")
+	}
+	// The jobs, tasks and queues are ordered once for the session.
+	if calls := atomic.LoadInt32(&orderCalls); calls != 3 {
+		t.Errorf("expected the order verbs to be called 3 times, called %d times", calls)
+	}
+
+	victims := ssn.VictimTasks([]*api.TaskInfo{task1, task2})
+	if len(victims) != 1 || !victims[task2] {
+		t.Errorf("expected task p-pg2 to be the only victim, got %v", victims)
+	}
+
+	if !ssn.Allocatable(queue, task1) || ssn.Allocatable(queue, task2) {
+		t.Errorf("expected only task p-pg1 to be allocatable")
+	}
+}
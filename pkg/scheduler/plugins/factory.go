@@ -34,6 +34,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote"
 	"volcano.sh/volcano/pkg/scheduler/plugins/rescheduling"
 	"volcano.sh/volcano/pkg/scheduler/plugins/resourcequota"
 	"volcano.sh/volcano/pkg/scheduler/plugins/sla"
@@ -70,6 +71,53 @@ func init() {
 	// Plugins for Extender
 	framework.RegisterPluginBuilder(extender.PluginName, extender.New)
 
+	// Plugins served out of tree over gRPC
+	framework.RegisterPluginBuilder(remote.PluginName, remote.New)
+
 	// Plugins for ResourceQuota
 	framework.RegisterPluginBuilder(resourcequota.PluginName, resourcequota.New)
+
+	// Arguments accepted by the plugins, plugins without arguments must not be given any
+	framework.RegisterPluginArgumentSchema(binpack.PluginName, binpack.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(deviceshare.PluginName, deviceshare.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(drf.PluginName, drf.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(extender.PluginName, extender.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(nodeorder.PluginName, nodeorder.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(numaaware.PluginName, numaaware.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(overcommit.PluginName, overcommit.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(predicates.PluginName, predicates.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(proportion.PluginName, proportion.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(remote.PluginName, remote.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(rescheduling.PluginName, rescheduling.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(sla.PluginName, sla.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(tasktopology.PluginName, tasktopology.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(tdm.PluginName, tdm.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(usage.PluginName, usage.ArgumentSchema)
+	for _, name := range []string{
+		capacity.PluginName,
+		cdp.PluginName,
+		conformance.PluginName,
+		gang.PluginName,
+		nodegroup.PluginName,
+		pdb.PluginName,
+		priority.PluginName,
+		resourcequota.PluginName,
+	} {
+		framework.RegisterPluginArgumentSchema(name, framework.ArgumentSchema{})
+	}
+
+	// Plugins which only order tasks and filter and score nodes, they may be configured in profiles
+	for _, name := range []string{
+		binpack.PluginName,
+		deviceshare.PluginName,
+		nodegroup.PluginName,
+		nodeorder.PluginName,
+		numaaware.PluginName,
+		predicates.PluginName,
+		priority.PluginName,
+		tasktopology.PluginName,
+		usage.PluginName,
+	} {
+		framework.RegisterPlacementPlugin(name)
+	}
 }
@@ -67,6 +67,18 @@ type nodeOrderPlugin struct {
 	pluginArguments framework.Arguments
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NodeAffinityWeight:      framework.IntArgument,
+	PodAffinityWeight:       framework.IntArgument,
+	LeastRequestedWeight:    framework.IntArgument,
+	BalancedResourceWeight:  framework.IntArgument,
+	MostRequestedWeight:     framework.IntArgument,
+	TaintTolerationWeight:   framework.IntArgument,
+	ImageLocalityWeight:     framework.IntArgument,
+	PodTopologySpreadWeight: framework.IntArgument,
+}
+
 // New function returns nodeorder plugin object.
 func New(arguments framework.Arguments) framework.Plugin {
 	return &nodeOrderPlugin{pluginArguments: arguments}
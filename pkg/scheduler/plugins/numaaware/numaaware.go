@@ -55,6 +55,11 @@ type numaPlugin struct {
 	taskBindNodeMap map[api.TaskID]string
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NumaTopoWeight: framework.IntArgument,
+}
+
 // New function returns prioritize plugin object.
 func New(arguments framework.Arguments) framework.Plugin {
 	plugin := &numaPlugin{
@@ -46,6 +46,11 @@ type overcommitPlugin struct {
 	overCommitFactor float64
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	overCommitFactor: framework.FloatArgument,
+}
+
 // New function returns overcommit plugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &overcommitPlugin{
@@ -84,6 +84,21 @@ type predicatesPlugin struct {
 	pluginArguments framework.Arguments
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NodeAffinityEnable:                framework.BoolArgument,
+	NodePortsEnable:                   framework.BoolArgument,
+	TaintTolerationEnable:             framework.BoolArgument,
+	PodAffinityEnable:                 framework.BoolArgument,
+	NodeVolumeLimitsEnable:            framework.BoolArgument,
+	VolumeZoneEnable:                  framework.BoolArgument,
+	PodTopologySpreadEnable:           framework.BoolArgument,
+	CachePredicate:                    framework.BoolArgument,
+	ProportionalPredicate:             framework.BoolArgument,
+	ProportionalResource:              framework.StringArgument,
+	ProportionalResourcesPrefix + "*": framework.FloatArgument,
+}
+
 // New return predicate plugin
 func New(arguments framework.Arguments) framework.Plugin {
 	return &predicatesPlugin{pluginArguments: arguments}
@@ -29,6 +29,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util/fairshare"
 )
 
 // PluginName indicates name of volcano scheduler plugin.
@@ -38,6 +39,10 @@ type proportionPlugin struct {
 	totalResource  *api.Resource
 	totalGuarantee *api.Resource
 	queueOpts      map[api.QueueID]*queueAttr
+	totalWeight    int32
+	// fairShare is nil unless the queues are ordered by their decayed usage too
+	fairShare *fairshare.Config
+	history   *fairshare.History
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 }
@@ -61,12 +66,16 @@ type queueAttr struct {
 	guarantee      *api.Resource
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = fairshare.ArgumentSchema
+
 // New return proportion action
 func New(arguments framework.Arguments) framework.Plugin {
 	return &proportionPlugin{
 		totalResource:   api.EmptyResource(),
 		totalGuarantee:  api.EmptyResource(),
 		queueOpts:       map[api.QueueID]*queueAttr{},
+		fairShare:       fairshare.NewConfig(arguments),
 		pluginArguments: arguments,
 	}
 }
@@ -76,6 +85,9 @@ func (pp *proportionPlugin) Name() string {
 }
 
 func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
+	// The capacity of the queues is the one of their active capacity window if any.
+	ssn.ApplyQueueCapacitySchedules()
+
 	// Prepare scheduling data for this session.
 	pp.totalResource.Add(ssn.TotalResource)
 
@@ -88,6 +100,13 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		pp.totalGuarantee.Add(guarantee)
 	}
 	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
+	for _, queue := range ssn.Queues {
+		pp.totalWeight += queue.Weight
+	}
+	if pp.fairShare != nil {
+		pp.history = fairshare.GetHistory(pp.fairShare)
+		pp.history.Update(ssn, pp.fairShare)
+	}
 	// Build attributes for Queues.
 	for _, job := range ssn.Jobs {
 		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
@@ -254,11 +273,12 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		lv := l.(*api.QueueInfo)
 		rv := r.(*api.QueueInfo)
 
-		if pp.queueOpts[lv.UID].share == pp.queueOpts[rv.UID].share {
+		lshare, rshare := pp.orderShare(pp.queueOpts[lv.UID]), pp.orderShare(pp.queueOpts[rv.UID])
+		if lshare == rshare {
 			return 0
 		}
 
-		if pp.queueOpts[lv.UID].share < pp.queueOpts[rv.UID].share {
+		if lshare < rshare {
 			return -1
 		}
 
@@ -390,6 +410,18 @@ func (pp *proportionPlugin) OnSessionClose(ssn *framework.Session) {
 	pp.totalResource = nil
 	pp.totalGuarantee = nil
 	pp.queueOpts = nil
+	pp.history = nil
+}
+
+// orderShare returns the share the queues are ordered by: the share of the queue, plus its decayed
+// usage relative to its weight if fair share is enabled, so that a queue which used more than its
+// weight recently comes after the others.
+func (pp *proportionPlugin) orderShare(attr *queueAttr) float64 {
+	if pp.history == nil || attr.weight <= 0 || pp.totalWeight <= 0 {
+		return attr.share
+	}
+	entitled := float64(attr.weight) / float64(pp.totalWeight)
+	return attr.share + pp.fairShare.Weight*pp.history.Share(attr.queueID)/entitled
 }
 
 func (pp *proportionPlugin) updateShare(attr *queueAttr) {
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote/sdk"
+)
+
+func resource(r *api.Resource) sdk.Resource {
+	if r == nil {
+		return nil
+	}
+	res := sdk.Resource{
+		string(v1.ResourceCPU):    r.MilliCPU,
+		string(v1.ResourceMemory): r.Memory,
+	}
+	for name, quantity := range r.ScalarResources {
+		res[string(name)] = quantity
+	}
+	return res
+}
+
+func convertTask(task *api.TaskInfo) *sdk.Task {
+	return &sdk.Task{
+		UID:       string(task.UID),
+		Job:       string(task.Job),
+		Name:      task.Name,
+		Namespace: task.Namespace,
+		Status:    task.Status.String(),
+		Node:      task.NodeName,
+		Priority:  task.Priority,
+		Resreq:    resource(task.Resreq),
+		Pod:       task.Pod,
+	}
+}
+
+func convertNode(node *api.NodeInfo) *sdk.Node {
+	n := &sdk.Node{
+		Name:        node.Name,
+		Allocatable: resource(node.Allocatable),
+		Idle:        resource(node.Idle),
+		Used:        resource(node.Used),
+	}
+	if node.Node != nil {
+		n.Labels = node.Node.Labels
+		n.Annotations = node.Node.Annotations
+		n.Taints = node.Node.Spec.Taints
+	}
+	return n
+}
+
+func convertJob(job *api.JobInfo) *sdk.Job {
+	j := &sdk.Job{
+		UID:               string(job.UID),
+		Name:              job.Name,
+		Namespace:         job.Namespace,
+		Queue:             string(job.Queue),
+		Priority:          job.Priority,
+		MinAvailable:      job.MinAvailable,
+		CreationTimestamp: job.CreationTimestamp,
+		TotalRequest:      resource(job.TotalRequest),
+	}
+	if job.PodGroup != nil {
+		j.Annotations = job.PodGroup.Annotations
+	}
+	return j
+}
+
+func openSessionRequest(ssn *framework.Session) *sdk.OpenSessionRequest {
+	req := &sdk.OpenSessionRequest{
+		Session: string(ssn.UID),
+		Nodes:   make([]*sdk.Node, 0, len(ssn.NodeList)),
+		Queues:  make([]*sdk.Queue, 0, len(ssn.Queues)),
+		Jobs:    make([]*sdk.Job, 0, len(ssn.Jobs)),
+	}
+	for _, node := range ssn.NodeList {
+		req.Nodes = append(req.Nodes, convertNode(node))
+	}
+	for _, queue := range ssn.Queues {
+		q := &sdk.Queue{Name: queue.Name, Weight: queue.Weight}
+		if queue.Queue != nil {
+			q.Annotations = queue.Queue.Annotations
+		}
+		req.Queues = append(req.Queues, q)
+	}
+	for _, job := range ssn.Jobs {
+		req.Jobs = append(req.Jobs, convertJob(job))
+	}
+	return req
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote/sdk"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "remote"
+
+	// RemoteAddress is the key for providing the gRPC address of the remote plugin
+	RemoteAddress = "remote.address"
+	// RemoteTimeout is the timeout for the calls to the remote plugin
+	RemoteTimeout = "remote.timeout"
+	// RemoteIgnorable indicates whether the remote plugin can ignore unexpected errors
+	RemoteIgnorable = "remote.ignorable"
+)
+
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	RemoteAddress:   framework.StringArgument,
+	RemoteTimeout:   framework.DurationArgument,
+	RemoteIgnorable: framework.BoolArgument,
+}
+
+// The plugin is built for every session, the connections are kept across sessions.
+var (
+	connMutex sync.Mutex
+	conns     = map[string]*grpc.ClientConn{}
+)
+
+func connect(address string) (*grpc.ClientConn, error) {
+	connMutex.Lock()
+	defer connMutex.Unlock()
+
+	if conn, found := conns[address]; found {
+		return conn, nil
+	}
+	conn, err := sdk.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	conns[address] = conn
+	return conn, nil
+}
+
+// predicateEntry holds the verdicts of all nodes for a task, they are fetched once.
+type predicateEntry struct {
+	once    sync.Once
+	results map[string]sdk.PredicateResult
+	err     error
+}
+
+type remotePlugin struct {
+	address   string
+	timeout   time.Duration
+	ignorable bool
+
+	client  sdk.PluginClient
+	session string
+
+	eventsMutex  sync.Mutex
+	events       sdk.EventsClient
+	eventsCancel context.CancelFunc
+
+	// predicates caches the verdicts per task, they are dropped whenever
+	// a task is allocated or deallocated as the idle resources change.
+	predicateMutex sync.Mutex
+	predicates     map[api.TaskID]*predicateEntry
+
+	// jobRanks is the position of the jobs in the order returned by the remote plugin.
+	jobRanks map[api.JobID]int
+}
+
+// New return remote plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	/*
+	   - name: remote
+	     arguments:
+	       remote.address: unix:///var/run/volcano/plugin.sock
+	       remote.timeout: 100ms
+	       remote.ignorable: true
+	*/
+	rp := &remotePlugin{
+		timeout:    time.Second,
+		predicates: map[api.TaskID]*predicateEntry{},
+		jobRanks:   map[api.JobID]int{},
+	}
+	rp.address, _ = arguments[RemoteAddress].(string)
+	arguments.GetBool(&rp.ignorable, RemoteIgnorable)
+	if timeout, _ := arguments[RemoteTimeout].(string); timeout != "" {
+		if timeoutDuration, err := time.ParseDuration(timeout); err == nil {
+			rp.timeout = timeoutDuration
+		}
+	}
+	return rp
+}
+
+func (rp *remotePlugin) Name() string {
+	return PluginName
+}
+
+func (rp *remotePlugin) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), rp.timeout)
+}
+
+func (rp *remotePlugin) OnSessionOpen(ssn *framework.Session) {
+	if rp.address == "" {
+		klog.Errorf("Argument %s of plugin %s is not set", RemoteAddress, PluginName)
+		return
+	}
+	conn, err := connect(rp.address)
+	if err != nil {
+		klog.Warningf("Failed to connect to remote plugin at %s: %v", rp.address, err)
+		return
+	}
+	rp.client = sdk.NewPluginClient(conn)
+	rp.session = string(ssn.UID)
+
+	ctx, cancel := rp.context()
+	resp, err := rp.client.OpenSession(ctx, openSessionRequest(ssn))
+	cancel()
+	if err != nil {
+		klog.Warningf("Failed to open session on remote plugin at %s, skip it in this session: %v", rp.address, err)
+		return
+	}
+
+	ctx, rp.eventsCancel = context.WithCancel(context.Background())
+	if rp.events, err = rp.client.Events(ctx); err != nil {
+		klog.Warningf("Failed to stream events to remote plugin at %s: %v", rp.address, err)
+	}
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			rp.sendEvent(sdk.AllocateEvent, event.Task)
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			rp.sendEvent(sdk.DeallocateEvent, event.Task)
+		},
+	})
+
+	for _, hook := range resp.Hooks {
+		switch hook {
+		case sdk.PredicateHook:
+			ssn.AddPredicateFn(rp.Name(), rp.predicateFn(ssn))
+		case sdk.NodeOrderHook:
+			ssn.AddBatchNodeOrderFn(rp.Name(), rp.nodeOrderFn)
+		case sdk.JobOrderHook:
+			rp.orderJobs(ssn)
+			ssn.AddJobOrderFn(rp.Name(), rp.jobOrderFn)
+		case sdk.PreemptableHook:
+			ssn.AddPreemptableFn(rp.Name(), rp.preemptableFn)
+		case sdk.JobEnqueueableHook:
+			ssn.AddJobEnqueueableFn(rp.Name(), rp.jobEnqueueableFn)
+		default:
+			klog.Warningf("Remote plugin at %s declared unknown hook %s", rp.address, hook)
+		}
+	}
+}
+
+func (rp *remotePlugin) OnSessionClose(ssn *framework.Session) {
+	if rp.client == nil {
+		return
+	}
+
+	rp.eventsMutex.Lock()
+	if rp.events != nil {
+		if _, err := rp.events.CloseAndRecv(); err != nil {
+			klog.Warningf("Failed to close event stream of remote plugin at %s: %v", rp.address, err)
+		}
+		rp.events = nil
+	}
+	if rp.eventsCancel != nil {
+		rp.eventsCancel()
+	}
+	rp.eventsMutex.Unlock()
+
+	ctx, cancel := rp.context()
+	defer cancel()
+	if _, err := rp.client.CloseSession(ctx, &sdk.CloseSessionRequest{Session: rp.session}); err != nil {
+		klog.Warningf("Failed to close session on remote plugin at %s: %v", rp.address, err)
+	}
+}
+
+func (rp *remotePlugin) sendEvent(eventType sdk.EventType, task *api.TaskInfo) {
+	rp.predicateMutex.Lock()
+	rp.predicates = map[api.TaskID]*predicateEntry{}
+	rp.predicateMutex.Unlock()
+
+	rp.eventsMutex.Lock()
+	defer rp.eventsMutex.Unlock()
+	if rp.events == nil {
+		return
+	}
+	err := rp.events.Send(&sdk.Event{
+		Session: rp.session,
+		Type:    eventType,
+		Task:    string(task.UID),
+		Node:    task.NodeName,
+		Resreq:  resource(task.Resreq),
+	})
+	if err != nil {
+		klog.Warningf("Failed to send %s event of task <%s/%s> to remote plugin at %s: %v",
+			eventType, task.Namespace, task.Name, rp.address, err)
+		rp.events = nil
+	}
+}
+
+// predicateFn asks the remote plugin for the verdicts of all nodes on the
+// first call for a task, so that it is called once per task.
+func (rp *remotePlugin) predicateFn(ssn *framework.Session) api.PredicateFn {
+	return func(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
+		rp.predicateMutex.Lock()
+		entry, found := rp.predicates[task.UID]
+		if !found {
+			entry = &predicateEntry{}
+			rp.predicates[task.UID] = entry
+		}
+		rp.predicateMutex.Unlock()
+
+		entry.once.Do(func() {
+			nodes := make([]string, 0, len(ssn.NodeList))
+			for _, n := range ssn.NodeList {
+				nodes = append(nodes, n.Name)
+			}
+			ctx, cancel := rp.context()
+			defer cancel()
+			resp, err := rp.client.Predicate(ctx, &sdk.PredicateRequest{Session: rp.session, Task: convertTask(task), Nodes: nodes})
+			if err != nil {
+				entry.err = err
+				return
+			}
+			entry.results = resp.Results
+		})
+
+		if entry.err != nil {
+			klog.Warningf("Predicate of remote plugin at %s failed with error %v", rp.address, entry.err)
+			if rp.ignorable {
+				return nil, nil
+			}
+			return nil, entry.err
+		}
+
+		result, found := entry.results[node.Name]
+		if !found {
+			result.Reason = fmt.Sprintf("no verdict for node %s", node.Name)
+		}
+		if !result.Passed {
+			status := &api.Status{Code: api.UnschedulableAndUnresolvable, Reason: result.Reason}
+			return []*api.Status{status}, fmt.Errorf("plugin %s predicates failed %s", PluginName, result.Reason)
+		}
+		return []*api.Status{{Code: api.Success}}, nil
+	}
+}
+
+func (rp *remotePlugin) nodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+
+	ctx, cancel := rp.context()
+	defer cancel()
+	resp, err := rp.client.NodeOrder(ctx, &sdk.NodeOrderRequest{Session: rp.session, Task: convertTask(task), Nodes: names})
+	if err != nil {
+		klog.Warningf("NodeOrder of remote plugin at %s failed with error %v", rp.address, err)
+		if rp.ignorable {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Scores, nil
+}
+
+// orderJobs asks the remote plugin for the order of all jobs of the session at once.
+func (rp *remotePlugin) orderJobs(ssn *framework.Session) {
+	jobs := make([]string, 0, len(ssn.Jobs))
+	for uid := range ssn.Jobs {
+		jobs = append(jobs, string(uid))
+	}
+
+	ctx, cancel := rp.context()
+	defer cancel()
+	resp, err := rp.client.JobOrder(ctx, &sdk.JobOrderRequest{Session: rp.session, Jobs: jobs})
+	if err != nil {
+		klog.Warningf("JobOrder of remote plugin at %s failed with error %v", rp.address, err)
+		return
+	}
+	for rank, uid := range resp.Jobs {
+		rp.jobRanks[api.JobID(uid)] = rank
+	}
+}
+
+func (rp *remotePlugin) jobOrderFn(l, r interface{}) int {
+	lRank, lFound := rp.jobRanks[l.(*api.JobInfo).UID]
+	rRank, rFound := rp.jobRanks[r.(*api.JobInfo).UID]
+	if !lFound || !rFound || lRank == rRank {
+		return 0
+	}
+	if lRank < rRank {
+		return -1
+	}
+	return 1
+}
+
+func (rp *remotePlugin) preemptableFn(preemptor *api.TaskInfo, preemptees []*api.TaskInfo) ([]*api.TaskInfo, int) {
+	req := &sdk.PreemptableRequest{
+		Session:    rp.session,
+		Preemptor:  convertTask(preemptor),
+		Preemptees: make([]*sdk.Task, 0, len(preemptees)),
+	}
+	for _, preemptee := range preemptees {
+		req.Preemptees = append(req.Preemptees, convertTask(preemptee))
+	}
+
+	ctx, cancel := rp.context()
+	defer cancel()
+	resp, err := rp.client.Preemptable(ctx, req)
+	if err != nil {
+		klog.Warningf("Preemptable of remote plugin at %s failed with error %v", rp.address, err)
+		if rp.ignorable {
+			return nil, util.Permit
+		}
+		return nil, util.Reject
+	}
+
+	victims := make(map[string]bool, len(resp.Victims))
+	for _, uid := range resp.Victims {
+		victims[uid] = true
+	}
+	var result []*api.TaskInfo
+	for _, preemptee := range preemptees {
+		if victims[string(preemptee.UID)] {
+			result = append(result, preemptee)
+		}
+	}
+	return result, resp.Status
+}
+
+func (rp *remotePlugin) jobEnqueueableFn(obj interface{}) int {
+	job := obj.(*api.JobInfo)
+
+	ctx, cancel := rp.context()
+	defer cancel()
+	resp, err := rp.client.JobEnqueueable(ctx, &sdk.JobEnqueueableRequest{Session: rp.session, Job: string(job.UID)})
+	if err != nil {
+		klog.Warningf("JobEnqueueable of remote plugin at %s failed with error %v", rp.address, err)
+		if rp.ignorable {
+			return util.Permit
+		}
+		return util.Reject
+	}
+	return resp.Status
+}
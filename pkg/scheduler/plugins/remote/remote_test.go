@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote/sdk"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote/sdk/sdktest"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// zonePlugin only accepts nodes labeled with a zone, prefers the nodes with
+// the most idle cpu and schedules the jobs by name in reverse order.
+type zonePlugin struct{}
+
+func (zp *zonePlugin) Name() string { return "zone" }
+
+func (zp *zonePlugin) Predicate(ssn *sdk.Session, task *sdk.Task, node *sdk.Node) (bool, string) {
+	if _, found := node.Labels["zone"]; !found {
+		return false, "node has no zone"
+	}
+	return true, ""
+}
+
+func (zp *zonePlugin) NodeOrder(ssn *sdk.Session, task *sdk.Task, node *sdk.Node) float64 {
+	return node.Idle["cpu"] / 1000
+}
+
+func (zp *zonePlugin) JobOrder(ssn *sdk.Session, l, r *sdk.Job) bool {
+	return l.Name > r.Name
+}
+
+func TestRemotePlugin(t *testing.T) {
+	server, err := sdktest.NewServer(&zonePlugin{})
+	if err != nil {
+		t.Fatalf("failed to serve remote plugin: %v", err)
+	}
+	defer server.Stop()
+
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), map[string]string{"zone": "a"}))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("8", "8Gi"), map[string]string{"zone": "b"}))
+	sc.AddOrUpdateNode(util.BuildNode("n3", api.BuildResourceList("8", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	for _, pg := range []string{"pg1", "pg2"} {
+		sc.AddPodGroupV1beta1(util.BuildPodGroup(pg, "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+		sc.AddPod(util.BuildPod("ns1", "p-"+pg, "", v1.PodPending, api.BuildResourceList("1", "1G"), pg, make(map[string]string), make(map[string]string)))
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+					EnabledJobOrder:  &trueValue,
+					Arguments:        framework.Arguments{RemoteAddress: server.Address},
+				},
+			},
+		},
+	}
+	ssn := framework.OpenSession(sc, tiers, nil)
+	defer framework.CloseSession(ssn)
+
+	var task *api.TaskInfo
+	for _, jobTask := range ssn.Jobs[api.JobID("ns1/pg1")].Tasks {
+		task = jobTask
+	}
+
+	for name, passed := range map[string]bool{"n1": true, "n2": true, "n3": false} {
+		_, err := ssn.PredicateFn(task, ssn.Nodes[name])
+		if (err == nil) != passed {
+			t.Errorf("expected predicate on node %s to pass: %v, got error %v", name, passed, err)
+		}
+	}
+
+	scores, err := ssn.BatchNodeOrderFn(task, []*api.NodeInfo{ssn.Nodes["n1"], ssn.Nodes["n2"]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scores["n1"] != 4 || scores["n2"] != 8 {
+		t.Errorf("expected scores 4 and 8, got %v", scores)
+	}
+
+	if !ssn.JobOrderFn(ssn.Jobs[api.JobID("ns1/pg2")], ssn.Jobs[api.JobID("ns1/pg1")]) {
+		t.Errorf("expected job pg2 to be ordered before job pg1")
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Plugin is a remote plugin. It implements the hooks it supports with the
+// Predicater, NodeOrderer, JobOrderer, Preempter and JobEnqueuer interfaces.
+type Plugin interface {
+	Name() string
+}
+
+// Predicater filters the nodes a task fits on.
+type Predicater interface {
+	Predicate(ssn *Session, task *Task, node *Node) (bool, string)
+}
+
+// NodeOrderer scores the nodes a task fits on, higher scores are preferred.
+type NodeOrderer interface {
+	NodeOrder(ssn *Session, task *Task, node *Node) float64
+}
+
+// JobOrderer returns whether job l is scheduled before job r.
+type JobOrderer interface {
+	JobOrder(ssn *Session, l, r *Job) bool
+}
+
+// Preempter selects the preemptees the preemptor may preempt and votes with Permit, Abstain or Reject.
+type Preempter interface {
+	Preemptable(ssn *Session, preemptor *Task, preemptees []*Task) ([]*Task, int)
+}
+
+// JobEnqueuer votes whether the job may be enqueued with Permit, Abstain or Reject.
+type JobEnqueuer interface {
+	JobEnqueueable(ssn *Session, job *Job) int
+}
+
+// Session is the state of the cluster in a scheduling session. The idle and
+// used resources of the nodes are kept up to date with the allocations made
+// in the session.
+type Session struct {
+	UID    string
+	Nodes  map[string]*Node
+	Queues map[string]*Queue
+	Jobs   map[string]*Job
+
+	mutex sync.RWMutex
+}
+
+// Node returns the node by name.
+func (ssn *Session) Node(name string) *Node {
+	ssn.mutex.RLock()
+	defer ssn.mutex.RUnlock()
+	return ssn.Nodes[name]
+}
+
+func (ssn *Session) apply(event *Event) {
+	ssn.mutex.Lock()
+	defer ssn.mutex.Unlock()
+
+	node, found := ssn.Nodes[event.Node]
+	if !found {
+		return
+	}
+	sign := 1.0
+	if event.Type == DeallocateEvent {
+		sign = -1.0
+	}
+	if node.Idle == nil {
+		node.Idle = Resource{}
+	}
+	if node.Used == nil {
+		node.Used = Resource{}
+	}
+	for name, quantity := range event.Resreq {
+		node.Idle[name] -= sign * quantity
+		node.Used[name] += sign * quantity
+	}
+}
+
+// server implements PluginServer with a Plugin.
+type server struct {
+	plugin Plugin
+
+	mutex    sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewServer returns the PluginServer calling the hooks implemented by the plugin.
+func NewServer(plugin Plugin) PluginServer {
+	return &server{
+		plugin:   plugin,
+		sessions: map[string]*Session{},
+	}
+}
+
+// Serve serves the plugin on the listener until it fails.
+func Serve(lis net.Listener, plugin Plugin, opts ...grpc.ServerOption) error {
+	s := grpc.NewServer(opts...)
+	RegisterPluginServer(s, NewServer(plugin))
+	return s.Serve(lis)
+}
+
+func (s *server) session(uid string) (*Session, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ssn, found := s.sessions[uid]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "session %s is not open", uid)
+	}
+	return ssn, nil
+}
+
+func (s *server) OpenSession(ctx context.Context, in *OpenSessionRequest) (*OpenSessionResponse, error) {
+	ssn := &Session{
+		UID:    in.Session,
+		Nodes:  make(map[string]*Node, len(in.Nodes)),
+		Queues: make(map[string]*Queue, len(in.Queues)),
+		Jobs:   make(map[string]*Job, len(in.Jobs)),
+	}
+	for _, node := range in.Nodes {
+		ssn.Nodes[node.Name] = node
+	}
+	for _, queue := range in.Queues {
+		ssn.Queues[queue.Name] = queue
+	}
+	for _, job := range in.Jobs {
+		ssn.Jobs[job.UID] = job
+	}
+
+	s.mutex.Lock()
+	s.sessions[in.Session] = ssn
+	s.mutex.Unlock()
+
+	var hooks []Hook
+	if _, ok := s.plugin.(Predicater); ok {
+		hooks = append(hooks, PredicateHook)
+	}
+	if _, ok := s.plugin.(NodeOrderer); ok {
+		hooks = append(hooks, NodeOrderHook)
+	}
+	if _, ok := s.plugin.(JobOrderer); ok {
+		hooks = append(hooks, JobOrderHook)
+	}
+	if _, ok := s.plugin.(Preempter); ok {
+		hooks = append(hooks, PreemptableHook)
+	}
+	if _, ok := s.plugin.(JobEnqueuer); ok {
+		hooks = append(hooks, JobEnqueueableHook)
+	}
+	return &OpenSessionResponse{Hooks: hooks}, nil
+}
+
+func (s *server) CloseSession(ctx context.Context, in *CloseSessionRequest) (*CloseSessionResponse, error) {
+	s.mutex.Lock()
+	delete(s.sessions, in.Session)
+	s.mutex.Unlock()
+	return &CloseSessionResponse{}, nil
+}
+
+func (s *server) Predicate(ctx context.Context, in *PredicateRequest) (*PredicateResponse, error) {
+	predicater, ok := s.plugin.(Predicater)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin %s does not implement %s", s.plugin.Name(), PredicateHook)
+	}
+	ssn, err := s.session(in.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PredicateResponse{Results: make(map[string]PredicateResult, len(in.Nodes))}
+	for _, name := range in.Nodes {
+		node := ssn.Node(name)
+		if node == nil {
+			resp.Results[name] = PredicateResult{Reason: fmt.Sprintf("node %s is unknown", name)}
+			continue
+		}
+		passed, reason := predicater.Predicate(ssn, in.Task, node)
+		resp.Results[name] = PredicateResult{Passed: passed, Reason: reason}
+	}
+	return resp, nil
+}
+
+func (s *server) NodeOrder(ctx context.Context, in *NodeOrderRequest) (*NodeOrderResponse, error) {
+	orderer, ok := s.plugin.(NodeOrderer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin %s does not implement %s", s.plugin.Name(), NodeOrderHook)
+	}
+	ssn, err := s.session(in.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &NodeOrderResponse{Scores: make(map[string]float64, len(in.Nodes))}
+	for _, name := range in.Nodes {
+		if node := ssn.Node(name); node != nil {
+			resp.Scores[name] = orderer.NodeOrder(ssn, in.Task, node)
+		}
+	}
+	return resp, nil
+}
+
+func (s *server) JobOrder(ctx context.Context, in *JobOrderRequest) (*JobOrderResponse, error) {
+	orderer, ok := s.plugin.(JobOrderer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin %s does not implement %s", s.plugin.Name(), JobOrderHook)
+	}
+	ssn, err := s.session(in.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(in.Jobs))
+	for _, uid := range in.Jobs {
+		if job, found := ssn.Jobs[uid]; found {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return orderer.JobOrder(ssn, jobs[i], jobs[j])
+	})
+
+	resp := &JobOrderResponse{Jobs: make([]string, 0, len(jobs))}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, job.UID)
+	}
+	return resp, nil
+}
+
+func (s *server) Preemptable(ctx context.Context, in *PreemptableRequest) (*PreemptableResponse, error) {
+	preempter, ok := s.plugin.(Preempter)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin %s does not implement %s", s.plugin.Name(), PreemptableHook)
+	}
+	ssn, err := s.session(in.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	victims, vote := preempter.Preemptable(ssn, in.Preemptor, in.Preemptees)
+	resp := &PreemptableResponse{Status: vote, Victims: make([]string, 0, len(victims))}
+	for _, victim := range victims {
+		resp.Victims = append(resp.Victims, victim.UID)
+	}
+	return resp, nil
+}
+
+func (s *server) JobEnqueueable(ctx context.Context, in *JobEnqueueableRequest) (*JobEnqueueableResponse, error) {
+	enqueuer, ok := s.plugin.(JobEnqueuer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin %s does not implement %s", s.plugin.Name(), JobEnqueueableHook)
+	}
+	ssn, err := s.session(in.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	job, found := ssn.Jobs[in.Job]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "job %s is unknown", in.Job)
+	}
+	return &JobEnqueueableResponse{Status: enqueuer.JobEnqueueable(ssn, job)}, nil
+}
+
+func (s *server) Events(stream EventsServer) error {
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&EventsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		ssn, err := s.session(event.Session)
+		if err != nil {
+			return err
+		}
+		ssn.apply(event)
+	}
+}
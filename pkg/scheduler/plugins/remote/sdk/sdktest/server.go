@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdktest serves remote plugins locally, to test them against the
+// scheduler, e.g. with the remote plugin configured with Server.Address.
+package sdktest
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote/sdk"
+)
+
+// Server serves a remote plugin on a local port.
+type Server struct {
+	// Address is the address the plugin is served on.
+	Address string
+
+	server *grpc.Server
+}
+
+// NewServer starts serving the plugin on a free local port.
+func NewServer(plugin sdk.Plugin) (*Server, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		Address: lis.Addr().String(),
+		server:  grpc.NewServer(),
+	}
+	sdk.RegisterPluginServer(s.server, sdk.NewServer(plugin))
+	go s.server.Serve(lis)
+	return s, nil
+}
+
+// Stop stops serving the plugin.
+func (s *Server) Stop() {
+	s.server.Stop()
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName is the name of the gRPC service implemented by remote plugins.
+const ServiceName = "volcano.scheduler.remote.v1.Plugin"
+
+// Codec is the content subtype the messages are encoded with. The messages
+// are plain json, so that plugins can be written in any language with gRPC
+// support without generated code.
+const Codec = "json"
+
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return Codec
+}
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+// PluginServer is the server API of remote plugins.
+type PluginServer interface {
+	OpenSession(context.Context, *OpenSessionRequest) (*OpenSessionResponse, error)
+	CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionResponse, error)
+	Predicate(context.Context, *PredicateRequest) (*PredicateResponse, error)
+	NodeOrder(context.Context, *NodeOrderRequest) (*NodeOrderResponse, error)
+	JobOrder(context.Context, *JobOrderRequest) (*JobOrderResponse, error)
+	Preemptable(context.Context, *PreemptableRequest) (*PreemptableResponse, error)
+	JobEnqueueable(context.Context, *JobEnqueueableRequest) (*JobEnqueueableResponse, error)
+	Events(EventsServer) error
+}
+
+// EventsServer receives the events of a session.
+type EventsServer interface {
+	Recv() (*Event, error)
+	SendAndClose(*EventsResponse) error
+	grpc.ServerStream
+}
+
+// EventsClient sends the events of a session.
+type EventsClient interface {
+	Send(*Event) error
+	CloseAndRecv() (*EventsResponse, error)
+	grpc.ClientStream
+}
+
+// PluginClient is the client API of remote plugins.
+type PluginClient interface {
+	OpenSession(ctx context.Context, in *OpenSessionRequest, opts ...grpc.CallOption) (*OpenSessionResponse, error)
+	CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionResponse, error)
+	Predicate(ctx context.Context, in *PredicateRequest, opts ...grpc.CallOption) (*PredicateResponse, error)
+	NodeOrder(ctx context.Context, in *NodeOrderRequest, opts ...grpc.CallOption) (*NodeOrderResponse, error)
+	JobOrder(ctx context.Context, in *JobOrderRequest, opts ...grpc.CallOption) (*JobOrderResponse, error)
+	Preemptable(ctx context.Context, in *PreemptableRequest, opts ...grpc.CallOption) (*PreemptableResponse, error)
+	JobEnqueueable(ctx context.Context, in *JobEnqueueableRequest, opts ...grpc.CallOption) (*JobEnqueueableResponse, error)
+	Events(ctx context.Context, opts ...grpc.CallOption) (EventsClient, error)
+}
+
+// Dial connects to the remote plugin at the address, e.g. "localhost:9090" or
+// "unix:///var/run/volcano/plugin.sock".
+func Dial(address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(Codec)),
+	}, opts...)
+	return grpc.Dial(address, opts...)
+}
+
+type pluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewPluginClient returns the client of the remote plugin on the connection.
+func NewPluginClient(cc grpc.ClientConnInterface) PluginClient {
+	return &pluginClient{cc: cc}
+}
+
+func (c *pluginClient) invoke(ctx context.Context, method string, in, out interface{}, opts ...grpc.CallOption) error {
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(Codec)}, opts...)
+	return c.cc.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, opts...)
+}
+
+func (c *pluginClient) OpenSession(ctx context.Context, in *OpenSessionRequest, opts ...grpc.CallOption) (*OpenSessionResponse, error) {
+	out := &OpenSessionResponse{}
+	return out, c.invoke(ctx, "OpenSession", in, out, opts...)
+}
+
+func (c *pluginClient) CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionResponse, error) {
+	out := &CloseSessionResponse{}
+	return out, c.invoke(ctx, "CloseSession", in, out, opts...)
+}
+
+func (c *pluginClient) Predicate(ctx context.Context, in *PredicateRequest, opts ...grpc.CallOption) (*PredicateResponse, error) {
+	out := &PredicateResponse{}
+	return out, c.invoke(ctx, "Predicate", in, out, opts...)
+}
+
+func (c *pluginClient) NodeOrder(ctx context.Context, in *NodeOrderRequest, opts ...grpc.CallOption) (*NodeOrderResponse, error) {
+	out := &NodeOrderResponse{}
+	return out, c.invoke(ctx, "NodeOrder", in, out, opts...)
+}
+
+func (c *pluginClient) JobOrder(ctx context.Context, in *JobOrderRequest, opts ...grpc.CallOption) (*JobOrderResponse, error) {
+	out := &JobOrderResponse{}
+	return out, c.invoke(ctx, "JobOrder", in, out, opts...)
+}
+
+func (c *pluginClient) Preemptable(ctx context.Context, in *PreemptableRequest, opts ...grpc.CallOption) (*PreemptableResponse, error) {
+	out := &PreemptableResponse{}
+	return out, c.invoke(ctx, "Preemptable", in, out, opts...)
+}
+
+func (c *pluginClient) JobEnqueueable(ctx context.Context, in *JobEnqueueableRequest, opts ...grpc.CallOption) (*JobEnqueueableResponse, error) {
+	out := &JobEnqueueableResponse{}
+	return out, c.invoke(ctx, "JobEnqueueable", in, out, opts...)
+}
+
+func (c *pluginClient) Events(ctx context.Context, opts ...grpc.CallOption) (EventsClient, error) {
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(Codec)}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServiceDesc.Streams[0], "/"+ServiceName+"/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &eventsClient{stream}, nil
+}
+
+type eventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsClient) Send(m *Event) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *eventsClient) CloseAndRecv() (*EventsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := &EventsResponse{}
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type eventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventsServer) Recv() (*Event, error) {
+	m := &Event{}
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *eventsServer) SendAndClose(m *EventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterPluginServer registers the remote plugin on the gRPC server.
+func RegisterPluginServer(s grpc.ServiceRegistrar, srv PluginServer) {
+	s.RegisterService(&ServiceDesc, srv)
+}
+
+// unaryHandler returns the handler decoding the request into a new value
+// of the request type and calling the method of the server with it.
+func unaryHandler[Req any, Resp any](method string, call func(PluginServer, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(Req)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return call(srv.(PluginServer), ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + ServiceName + "/" + method,
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(srv.(PluginServer), ctx, req.(*Req))
+			}
+			return interceptor(ctx, in, info, handler)
+		},
+	}
+}
+
+// ServiceDesc is the gRPC service description of remote plugins.
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*PluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryHandler("OpenSession", PluginServer.OpenSession),
+		unaryHandler("CloseSession", PluginServer.CloseSession),
+		unaryHandler("Predicate", PluginServer.Predicate),
+		unaryHandler("NodeOrder", PluginServer.NodeOrder),
+		unaryHandler("JobOrder", PluginServer.JobOrder),
+		unaryHandler("Preemptable", PluginServer.Preemptable),
+		unaryHandler("JobEnqueueable", PluginServer.JobEnqueueable),
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "Events",
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(PluginServer).Events(&eventsServer{stream})
+			},
+			ClientStreams: true,
+		},
+	},
+}
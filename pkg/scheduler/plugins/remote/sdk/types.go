@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk defines the gRPC protocol between vc-scheduler and out-of-tree
+// scheduler plugins, and helps to implement such plugins in Go.
+//
+// The scheduler opens a session on the plugin with a snapshot of the nodes,
+// queues and jobs, streams the allocations made in the session to it, and
+// calls the hooks the plugin declared for batches of nodes or jobs, so that
+// the plugin is called once per task instead of once per task and node.
+package sdk
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Hook is a scheduling function a remote plugin implements.
+type Hook string
+
+const (
+	// PredicateHook filters the nodes a task fits on.
+	PredicateHook Hook = "Predicate"
+	// NodeOrderHook scores the nodes a task fits on.
+	NodeOrderHook Hook = "NodeOrder"
+	// JobOrderHook orders the jobs of the session.
+	JobOrderHook Hook = "JobOrder"
+	// PreemptableHook selects the tasks a task may preempt.
+	PreemptableHook Hook = "Preemptable"
+	// JobEnqueueableHook votes whether a job may be enqueued.
+	JobEnqueueableHook Hook = "JobEnqueueable"
+)
+
+const (
+	// Permit is the vote of a hook permitting the operation.
+	Permit = 1
+	// Abstain is the vote of a hook leaving the decision to the other plugins.
+	Abstain = 0
+	// Reject is the vote of a hook rejecting the operation.
+	Reject = -1
+)
+
+// Resource maps resource names to quantities, cpu is in millicores and memory in bytes.
+type Resource map[string]float64
+
+// Node is a node of the cluster.
+type Node struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Taints      []v1.Taint        `json:"taints,omitempty"`
+	Allocatable Resource          `json:"allocatable"`
+	Idle        Resource          `json:"idle"`
+	Used        Resource          `json:"used"`
+}
+
+// Queue is a queue of jobs.
+type Queue struct {
+	Name        string            `json:"name"`
+	Weight      int32             `json:"weight"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Job is a PodGroup and its tasks.
+type Job struct {
+	UID          string `json:"uid"`
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Queue        string `json:"queue"`
+	Priority     int32  `json:"priority"`
+	MinAvailable int32  `json:"minAvailable"`
+	// Annotations are the annotations of the PodGroup.
+	Annotations       map[string]string `json:"annotations,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	TotalRequest      Resource          `json:"totalRequest"`
+}
+
+// Task is a pod of a job.
+type Task struct {
+	UID       string   `json:"uid"`
+	Job       string   `json:"job"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Status    string   `json:"status"`
+	Node      string   `json:"node,omitempty"`
+	Priority  int32    `json:"priority"`
+	Resreq    Resource `json:"resreq"`
+	Pod       *v1.Pod  `json:"pod,omitempty"`
+}
+
+// EventType is the kind of change made to the nodes in a session.
+type EventType string
+
+const (
+	// AllocateEvent means the task was placed on the node.
+	AllocateEvent EventType = "Allocate"
+	// DeallocateEvent means the task was removed from the node, e.g. evicted.
+	DeallocateEvent EventType = "Deallocate"
+)
+
+// Event is an allocation made in the session, it is streamed to the plugin.
+type Event struct {
+	Session string    `json:"session"`
+	Type    EventType `json:"type"`
+	Task    string    `json:"task"`
+	Node    string    `json:"node"`
+	Resreq  Resource  `json:"resreq"`
+}
+
+// EventsResponse acknowledges the events of a session.
+type EventsResponse struct{}
+
+// OpenSessionRequest opens a session with a snapshot of the cluster.
+type OpenSessionRequest struct {
+	Session string   `json:"session"`
+	Nodes   []*Node  `json:"nodes"`
+	Queues  []*Queue `json:"queues"`
+	Jobs    []*Job   `json:"jobs"`
+}
+
+// OpenSessionResponse declares the hooks the plugin implements, the scheduler calls no other hook.
+type OpenSessionResponse struct {
+	Hooks []Hook `json:"hooks"`
+}
+
+// CloseSessionRequest closes the session, the plugin can drop its state.
+type CloseSessionRequest struct {
+	Session string `json:"session"`
+}
+
+// CloseSessionResponse acknowledges the end of the session.
+type CloseSessionResponse struct{}
+
+// PredicateRequest asks which of the nodes the task fits on.
+type PredicateRequest struct {
+	Session string   `json:"session"`
+	Task    *Task    `json:"task"`
+	Nodes   []string `json:"nodes"`
+}
+
+// PredicateResult is the verdict for a node.
+type PredicateResult struct {
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PredicateResponse holds the verdict for each node of the request.
+type PredicateResponse struct {
+	Results map[string]PredicateResult `json:"results"`
+}
+
+// NodeOrderRequest asks for the score of the nodes for the task.
+type NodeOrderRequest struct {
+	Session string   `json:"session"`
+	Task    *Task    `json:"task"`
+	Nodes   []string `json:"nodes"`
+}
+
+// NodeOrderResponse holds the score of the nodes, higher scores are preferred.
+type NodeOrderResponse struct {
+	Scores map[string]float64 `json:"scores"`
+}
+
+// JobOrderRequest asks for the order the jobs are scheduled in.
+type JobOrderRequest struct {
+	Session string   `json:"session"`
+	Jobs    []string `json:"jobs"`
+}
+
+// JobOrderResponse holds the jobs in the order they are scheduled in,
+// jobs missing in the response are left to the other plugins.
+type JobOrderResponse struct {
+	Jobs []string `json:"jobs"`
+}
+
+// PreemptableRequest asks which of the preemptees the preemptor may preempt.
+type PreemptableRequest struct {
+	Session    string  `json:"session"`
+	Preemptor  *Task   `json:"preemptor"`
+	Preemptees []*Task `json:"preemptees"`
+}
+
+// PreemptableResponse holds the tasks which may be preempted and the vote of the plugin.
+type PreemptableResponse struct {
+	Victims []string `json:"victims"`
+	Status  int      `json:"status"`
+}
+
+// JobEnqueueableRequest asks whether the job may be enqueued.
+type JobEnqueueableRequest struct {
+	Session string `json:"session"`
+	Job     string `json:"job"`
+}
+
+// JobEnqueueableResponse holds the vote of the plugin.
+type JobEnqueueableResponse struct {
+	Status int `json:"status"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// HighNodeUtilizationConf is the configuration of the HNU strategy, which evicts the pods of the
+// underutilized nodes so that they are compacted onto the other nodes and the underutilized nodes
+// can be scaled down.
+type HighNodeUtilizationConf struct {
+	// Thresholds are the utilization under which, for all resources, the nodes are underutilized
+	Thresholds map[string]float64 `mapstructure:"thresholds"`
+	// TargetThresholds are the utilization up to which the other nodes are filled
+	TargetThresholds map[string]float64 `mapstructure:"targetThresholds"`
+}
+
+// NewHighNodeUtilizationConf returns the pointer of HighNodeUtilizationConf object with default value
+func NewHighNodeUtilizationConf() *HighNodeUtilizationConf {
+	return &HighNodeUtilizationConf{
+		Thresholds:       map[string]float64{"cpu": 20, "memory": 20},
+		TargetThresholds: map[string]float64{"cpu": 100, "memory": 100},
+	}
+}
+
+var victimsFnForHnu = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	hnuConfig := NewHighNodeUtilizationConf()
+	parseStrategyConf("highNodeUtilization", hnuConfig)
+	// The node utilization helpers are driven by the LNU configuration.
+	utilizationConfig := LowNodeUtilizationConf{
+		Thresholds:       hnuConfig.Thresholds,
+		TargetThresholds: hnuConfig.TargetThresholds,
+	}
+
+	// group the nodes into the underutilized nodes and the nodes receiving their pods
+	nodeUtilizationList := getNodeUtilization()
+	lowNodes, targetNodes := groupNodesByUtilization(nodeUtilizationList, lowThresholdFilter, schedulableFilter, utilizationConfig)
+	if len(lowNodes) == 0 {
+		klog.V(4).Infof("No node is underutilized")
+		return victims
+	}
+	if len(targetNodes) == 0 {
+		klog.V(4).Infof("No node can receive the pods of the underutilized nodes")
+		return victims
+	}
+
+	// evict the pods of the least utilized nodes first
+	sortNodesAscending(lowNodes)
+	return evictPodsFromSourceNodes(lowNodes, targetNodes, tasks, isContinueCompactPods, utilizationConfig)
+}
+
+// schedulableFilter filter nodes which are schedulable
+func schedulableFilter(usage *NodeUtilization, config interface{}) bool {
+	return !usage.nodeInfo.Spec.Unschedulable
+}
+
+// isContinueCompactPods judges whether the target nodes can still receive pods
+func isContinueCompactPods(usage *NodeUtilization, totalAllocatableResource map[v1.ResourceName]*resource.Quantity, config interface{}) bool {
+	for _, amount := range totalAllocatableResource {
+		if amount.CmpInt64(0) <= 0 {
+			return false
+		}
+	}
+	return true
+}
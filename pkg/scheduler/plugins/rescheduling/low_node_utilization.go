@@ -137,7 +137,8 @@ var victimsFnForLnu = func(tasks []*api.TaskInfo) []*api.TaskInfo {
 		return victims
 	}
 
-	// select victims from lowNodes
+	// select victims from highNodes, in descending order of utilization
+	sortNodes(highNodes)
 	return evictPodsFromSourceNodes(highNodes, lowNodes, tasks, isContinueEvictPods, *utilizationConfig)
 }
 
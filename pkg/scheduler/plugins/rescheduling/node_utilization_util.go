@@ -72,7 +72,8 @@ func getNodeUtilization() []*NodeUtilization {
 	return nodeUtilizationList
 }
 
-// evictPodsFromSourceNodes evict pods from source nodes to target nodes according to priority and QoS
+// evictPodsFromSourceNodes evict pods from source nodes to target nodes according to priority and QoS,
+// the source nodes are visited in order
 func evictPodsFromSourceNodes(sourceNodes, targetNodes []*NodeUtilization, tasks []*api.TaskInfo, evictionCon isContinueEviction, config interface{}) []*api.TaskInfo {
 	resourceNames := []v1.ResourceName{
 		v1.ResourceCPU,
@@ -92,9 +93,6 @@ func evictPodsFromSourceNodes(sourceNodes, targetNodes []*NodeUtilization, tasks
 	}
 	klog.V(4).Infof("totalAllocatableResource: %s", totalAllocatableResource)
 
-	// sort the source nodes in descending order
-	sortNodes(sourceNodes)
-
 	// victims select algorithm:
 	// 1. Evict pods from nodes with high utilization to low utilization
 	// 2. As to one node, evict pods from low priority to high priority. If the priority is same, evict pods according to QoS from low to high
@@ -121,7 +119,7 @@ func parseArgToConfig(config interface{}) *LowNodeUtilizationConf {
 	return utilizationConfig
 }
 
-// sortNodes sorts all the nodes according the usage of cpu and memory with weight score
+// sortNodes sorts all the nodes in descending order according the usage of cpu and memory with weight score
 func sortNodes(nodeUtilizationList []*NodeUtilization) {
 	cmpFn := func(i, j int) bool {
 		return getScoreForNode(i, nodeUtilizationList) > getScoreForNode(j, nodeUtilizationList)
@@ -129,6 +127,14 @@ func sortNodes(nodeUtilizationList []*NodeUtilization) {
 	sort.Slice(nodeUtilizationList, cmpFn)
 }
 
+// sortNodesAscending sorts all the nodes in ascending order according the usage of cpu and memory with weight score
+func sortNodesAscending(nodeUtilizationList []*NodeUtilization) {
+	cmpFn := func(i, j int) bool {
+		return getScoreForNode(i, nodeUtilizationList) < getScoreForNode(j, nodeUtilizationList)
+	}
+	sort.Slice(nodeUtilizationList, cmpFn)
+}
+
 // getScoreForNode returns the score for node which considers only for CPU and memory
 func getScoreForNode(index int, nodeUtilizationList []*NodeUtilization) float64 {
 	cpuScore := nodeUtilizationList[index].utilization[v1.ResourceCPU]
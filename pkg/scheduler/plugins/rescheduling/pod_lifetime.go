@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// PodLifeTimeConf is the configuration of the strategy evicting the pods running for too long
+type PodLifeTimeConf struct {
+	// MaxPodLifeTimeSeconds is the time after which the pods are evicted, the strategy is disabled if it is not set
+	MaxPodLifeTimeSeconds int64 `mapstructure:"maxPodLifeTimeSeconds"`
+}
+
+var victimsFnForPodLifeTime = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := &PodLifeTimeConf{}
+	parseStrategyConf("podLifeTime", config)
+	if config.MaxPodLifeTimeSeconds <= 0 {
+		klog.V(4).Infof("The max pod lifetime is not set, no pod is evicted.")
+		return victims
+	}
+
+	maxLifeTime := time.Duration(config.MaxPodLifeTimeSeconds) * time.Second
+	now := time.Now()
+	for _, task := range tasks {
+		startTime := task.Pod.CreationTimestamp.Time
+		if task.Pod.Status.StartTime != nil {
+			startTime = task.Pod.Status.StartTime.Time
+		}
+		if now.Sub(startTime) > maxLifeTime {
+			klog.V(3).Infof("Task <%s/%s> has been running since %v, longer than %v.", task.Namespace, task.Name, startTime, maxLifeTime)
+			victims = append(victims, task)
+		}
+	}
+	return victims
+}
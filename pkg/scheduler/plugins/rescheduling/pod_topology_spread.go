@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// PodTopologySpreadConf is the configuration of the strategy evicting pods from the topology domains
+// which run too many of them, so that their topology spread constraints are satisfied again.
+type PodTopologySpreadConf struct {
+	// IncludeSoftConstraints balances the ScheduleAnyway constraints too
+	IncludeSoftConstraints bool `mapstructure:"includeSoftConstraints"`
+}
+
+// topologySpread is a topology spread constraint shared by pods of a namespace
+type topologySpread struct {
+	namespace  string
+	constraint v1.TopologySpreadConstraint
+	selector   labels.Selector
+	// candidates are the tasks with the constraint which may be evicted, keyed by domain
+	candidates map[string][]*api.TaskInfo
+}
+
+var victimsFnForTopologySpread = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := &PodTopologySpreadConf{}
+	parseStrategyConf("podTopologySpread", config)
+
+	spreads := map[string]*topologySpread{}
+	for _, task := range tasks {
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		for _, constraint := range task.Pod.Spec.TopologySpreadConstraints {
+			if constraint.WhenUnsatisfiable != v1.DoNotSchedule && !config.IncludeSoftConstraints {
+				continue
+			}
+			domain, found := node.Node.Labels[constraint.TopologyKey]
+			if !found {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%d/%s", task.Namespace, constraint.TopologyKey, constraint.MaxSkew, metav1.FormatLabelSelector(constraint.LabelSelector))
+			spread, found := spreads[key]
+			if !found {
+				selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+				if err != nil {
+					klog.Warningf("Invalid label selector of the topology spread constraint of Task <%s/%s>: %v", task.Namespace, task.Name, err)
+					continue
+				}
+				spread = &topologySpread{
+					namespace:  task.Namespace,
+					constraint: constraint,
+					selector:   selector,
+					candidates: map[string][]*api.TaskInfo{},
+				}
+				spreads[key] = spread
+			}
+			spread.candidates[domain] = append(spread.candidates[domain], task)
+		}
+	}
+
+	evicted := map[api.TaskID]bool{}
+	for _, spread := range spreads {
+		for _, victim := range spread.victims() {
+			if !evicted[victim.UID] {
+				evicted[victim.UID] = true
+				victims = append(victims, victim)
+			}
+		}
+	}
+	return victims
+}
+
+// victims returns the candidates to evict from the largest domains until the skew does not exceed
+// the max skew. The evicted pods are expected to be rescheduled in the smallest domains.
+func (ts *topologySpread) victims() []*api.TaskInfo {
+	counts := map[string]int{}
+	for _, node := range Session.Nodes {
+		if node.Node == nil {
+			continue
+		}
+		domain, found := node.Node.Labels[ts.constraint.TopologyKey]
+		if !found {
+			continue
+		}
+		if _, found := counts[domain]; !found {
+			counts[domain] = 0
+		}
+		for _, task := range node.Tasks {
+			if task.Pod != nil && task.Namespace == ts.namespace && ts.selector.Matches(labels.Set(task.Pod.Labels)) {
+				counts[domain]++
+			}
+		}
+	}
+	for _, candidates := range ts.candidates {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Priority < candidates[j].Priority
+		})
+	}
+
+	var victims []*api.TaskInfo
+	for {
+		maxDomain, minDomain := "", ""
+		for domain, count := range counts {
+			if maxDomain == "" || count > counts[maxDomain] || (count == counts[maxDomain] && domain < maxDomain) {
+				maxDomain = domain
+			}
+			if minDomain == "" || count < counts[minDomain] || (count == counts[minDomain] && domain < minDomain) {
+				minDomain = domain
+			}
+		}
+		if maxDomain == "" || int32(counts[maxDomain]-counts[minDomain]) <= ts.constraint.MaxSkew {
+			return victims
+		}
+		candidates := ts.candidates[maxDomain]
+		if len(candidates) == 0 {
+			klog.V(4).Infof("No pod can be evicted from the topology domain %s=%s.", ts.constraint.TopologyKey, maxDomain)
+			return victims
+		}
+		victims = append(victims, candidates[0])
+		ts.candidates[maxDomain] = candidates[1:]
+		counts[maxDomain]--
+		counts[minDomain]++
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// RemoveDuplicatesConf is the configuration of the strategy evicting the replicas of the same task
+// of a job running on the same node, so that they are spread over the nodes.
+type RemoveDuplicatesConf struct {
+	// ExcludeOwnerKinds are the kinds of the owners of the pods which are not evicted, e.g. "StatefulSet"
+	ExcludeOwnerKinds []string `mapstructure:"excludeOwnerKinds"`
+}
+
+var victimsFnForDuplicates = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := &RemoveDuplicatesConf{}
+	parseStrategyConf("removeDuplicates", config)
+	excluded := make(map[string]bool, len(config.ExcludeOwnerKinds))
+	for _, kind := range config.ExcludeOwnerKinds {
+		excluded[kind] = true
+	}
+
+	schedulableNodes := 0
+	for _, node := range Session.Nodes {
+		if node.Node != nil && !node.Node.Spec.Unschedulable {
+			schedulableNodes++
+		}
+	}
+	if schedulableNodes == 0 {
+		return victims
+	}
+
+	// group the replicas by job and task, and then by node
+	replicas := map[string]map[string][]*api.TaskInfo{}
+	for _, task := range tasks {
+		if owner := metav1.GetControllerOf(task.Pod); owner != nil && excluded[owner.Kind] {
+			continue
+		}
+		key := string(task.Job) + "/" + task.Pod.Annotations[batch.TaskSpecKey]
+		if replicas[key] == nil {
+			replicas[key] = map[string][]*api.TaskInfo{}
+		}
+		replicas[key][task.NodeName] = append(replicas[key][task.NodeName], task)
+	}
+
+	for key, nodes := range replicas {
+		total := 0
+		for _, nodeReplicas := range nodes {
+			total += len(nodeReplicas)
+		}
+		// The replicas are evenly spread if no node runs more than upper replicas.
+		upper := (total + schedulableNodes - 1) / schedulableNodes
+		for nodeName, nodeReplicas := range nodes {
+			if len(nodeReplicas) <= upper {
+				continue
+			}
+			klog.V(3).Infof("%d replicas of %s run on node %s, %d at most are expected.", len(nodeReplicas), key, nodeName, upper)
+			sort.Slice(nodeReplicas, func(i, j int) bool {
+				return nodeReplicas[i].Priority < nodeReplicas[j].Priority
+			})
+			victims = append(victims, nodeReplicas[:len(nodeReplicas)-upper]...)
+		}
+	}
+	return victims
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	v1 "k8s.io/api/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// RemovePodsViolatingNodeAffinityConf is the configuration of the strategy evicting the pods whose
+// required node affinity or node selector is not satisfied by their node anymore, e.g. after the
+// labels of the node changed.
+type RemovePodsViolatingNodeAffinityConf struct {
+	// NodeFit evicts the pods only if they fit on another node
+	NodeFit bool `mapstructure:"nodeFit"`
+}
+
+// RemovePodsViolatingNodeTaintsConf is the configuration of the strategy evicting the pods which do
+// not tolerate the NoSchedule taints of their node anymore, e.g. after the node was tainted.
+type RemovePodsViolatingNodeTaintsConf struct {
+	// NodeFit evicts the pods only if they fit on another node
+	NodeFit bool `mapstructure:"nodeFit"`
+	// IncludePreferNoSchedule considers the PreferNoSchedule taints too
+	IncludePreferNoSchedule bool `mapstructure:"includePreferNoSchedule"`
+	// ExcludedTaints are the keys of the taints which are ignored
+	ExcludedTaints []string `mapstructure:"excludedTaints"`
+}
+
+var victimsFnForNodeAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := &RemovePodsViolatingNodeAffinityConf{NodeFit: true}
+	parseStrategyConf("removePodsViolatingNodeAffinity", config)
+
+	for _, task := range tasks {
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		affinity := nodeaffinity.GetRequiredNodeAffinity(task.Pod)
+		if match, _ := affinity.Match(node.Node); match {
+			continue
+		}
+		if config.NodeFit && !fitsOtherNode(task, func(node *api.NodeInfo) bool {
+			match, _ := affinity.Match(node.Node)
+			return match
+		}) {
+			klog.V(4).Infof("Task <%s/%s> violates the node affinity of node %s but fits on no other node.", task.Namespace, task.Name, task.NodeName)
+			continue
+		}
+		klog.V(3).Infof("Task <%s/%s> violates the node affinity of node %s.", task.Namespace, task.Name, task.NodeName)
+		victims = append(victims, task)
+	}
+	return victims
+}
+
+var victimsFnForNodeTaints = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := &RemovePodsViolatingNodeTaintsConf{NodeFit: true}
+	parseStrategyConf("removePodsViolatingNodeTaints", config)
+	excluded := make(map[string]bool, len(config.ExcludedTaints))
+	for _, key := range config.ExcludedTaints {
+		excluded[key] = true
+	}
+	taintFilter := func(taint *v1.Taint) bool {
+		if excluded[taint.Key] {
+			return false
+		}
+		return taint.Effect == v1.TaintEffectNoSchedule ||
+			(config.IncludePreferNoSchedule && taint.Effect == v1.TaintEffectPreferNoSchedule)
+	}
+	tolerated := func(task *api.TaskInfo, node *v1.Node) bool {
+		_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, task.Pod.Spec.Tolerations, taintFilter)
+		return !untolerated
+	}
+
+	for _, task := range tasks {
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil || tolerated(task, node.Node) {
+			continue
+		}
+		if config.NodeFit && !fitsOtherNode(task, func(node *api.NodeInfo) bool {
+			return tolerated(task, node.Node)
+		}) {
+			klog.V(4).Infof("Task <%s/%s> does not tolerate the taints of node %s but fits on no other node.", task.Namespace, task.Name, task.NodeName)
+			continue
+		}
+		klog.V(3).Infof("Task <%s/%s> does not tolerate the taints of node %s.", task.Namespace, task.Name, task.NodeName)
+		victims = append(victims, task)
+	}
+	return victims
+}
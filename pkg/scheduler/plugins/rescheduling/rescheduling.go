@@ -59,6 +59,12 @@ func init() {
 
 	// register victim functions for all strategies here
 	VictimFn["lowNodeUtilization"] = victimsFnForLnu
+	VictimFn["highNodeUtilization"] = victimsFnForHnu
+	VictimFn["removePodsViolatingNodeAffinity"] = victimsFnForNodeAffinity
+	VictimFn["removePodsViolatingNodeTaints"] = victimsFnForNodeTaints
+	VictimFn["removeDuplicates"] = victimsFnForDuplicates
+	VictimFn["podTopologySpread"] = victimsFnForTopologySpread
+	VictimFn["podLifeTime"] = victimsFnForPodLifeTime
 }
 
 type reschedulingPlugin struct {
@@ -66,6 +72,13 @@ type reschedulingPlugin struct {
 	pluginArguments framework.Arguments
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	"interval":      framework.DurationArgument,
+	"metricsPeriod": framework.DurationArgument,
+	"strategies":    framework.ListArgument,
+}
+
 // New function returns rescheduling plugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &reschedulingPlugin{
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// openSession opens a session with the nodes and the running pods of job pg1, and returns
+// the running tasks.
+func openSession(t *testing.T, nodes []*v1.Node, pods []*v1.Pod, usage map[string]float64) []*api.TaskInfo {
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	for _, node := range nodes {
+		sc.AddOrUpdateNode(node)
+		sc.Nodes[node.Name].ResourceUsage = &api.NodeUsage{
+			CPUUsageAvg: map[string]float64{MetricsPeriod: usage[node.Name]},
+			MEMUsageAvg: map[string]float64{MetricsPeriod: usage[node.Name]},
+		}
+	}
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning))
+	for _, pod := range pods {
+		sc.AddPod(pod)
+	}
+
+	Session = framework.OpenSession(sc, nil, nil)
+	t.Cleanup(func() {
+		framework.CloseSession(Session)
+		Session = nil
+		for k := range RegisteredStrategyConfigs {
+			delete(RegisteredStrategyConfigs, k)
+		}
+	})
+
+	var tasks []*api.TaskInfo
+	for _, task := range Session.Jobs[api.JobID("ns1/pg1")].Tasks {
+		if task.Status == api.Running {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// buildNode returns a node with 4 cpus, 8Gi memory and room for 10 pods.
+func buildNode(name string, labels map[string]string) *v1.Node {
+	return util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), labels)
+}
+
+func buildPod(name, nodeName string, labels map[string]string) *v1.Pod {
+	return util.BuildPod("ns1", name, nodeName, v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", labels, make(map[string]string))
+}
+
+func victimNames(victims []*api.TaskInfo) []string {
+	names := make([]string, 0, len(victims))
+	for _, victim := range victims {
+		names = append(names, victim.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkVictims(t *testing.T, victims []*api.TaskInfo, expected ...string) {
+	t.Helper()
+	names := victimNames(victims)
+	if len(names) != len(expected) {
+		t.Fatalf("expected victims %v, got %v", expected, names)
+	}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Fatalf("expected victims %v, got %v", expected, names)
+		}
+	}
+}
+
+func TestHighNodeUtilization(t *testing.T) {
+	nodes := []*v1.Node{
+		buildNode("n1", map[string]string{}),
+		buildNode("n2", map[string]string{}),
+	}
+	pods := []*v1.Pod{buildPod("p1", "n1", nil), buildPod("p2", "n2", nil)}
+	tasks := openSession(t, nodes, pods, map[string]float64{"n1": 10, "n2": 50})
+	RegisteredStrategyConfigs["highNodeUtilization"] = map[string]interface{}{
+		"thresholds": map[interface{}]interface{}{"cpu": 20, "memory": 20},
+	}
+
+	checkVictims(t, victimsFnForHnu(tasks), "p1")
+}
+
+func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
+	nodes := []*v1.Node{
+		buildNode("n1", map[string]string{"zone": "b"}),
+		buildNode("n2", map[string]string{"zone": "a"}),
+	}
+	p1 := buildPod("p1", "n1", nil)
+	p1.Spec.NodeSelector = map[string]string{"zone": "a"}
+	p2 := buildPod("p2", "n1", nil)
+	p3 := buildPod("p3", "n1", nil)
+	p3.Spec.NodeSelector = map[string]string{"zone": "c"}
+	tasks := openSession(t, nodes, []*v1.Pod{p1, p2, p3}, nil)
+
+	// p3 fits on no other node.
+	checkVictims(t, victimsFnForNodeAffinity(tasks), "p1")
+
+	RegisteredStrategyConfigs["removePodsViolatingNodeAffinity"] = map[string]interface{}{"nodeFit": false}
+	checkVictims(t, victimsFnForNodeAffinity(tasks), "p1", "p3")
+}
+
+func TestRemovePodsViolatingNodeAffinityNodeFit(t *testing.T) {
+	nodes := []*v1.Node{
+		buildNode("n1", map[string]string{"zone": "b"}),
+		buildNode("n2", map[string]string{"zone": "a"}),
+	}
+	p1 := buildPod("p1", "n1", nil)
+	p1.Spec.NodeSelector = map[string]string{"zone": "a"}
+	// p2 takes all the cpus of n2.
+	p2 := util.BuildPod("ns1", "p2", "n2", v1.PodRunning, api.BuildResourceList("4", "1Gi"), "pg1", nil, make(map[string]string))
+	tasks := openSession(t, nodes, []*v1.Pod{p1, p2}, nil)
+
+	// p1 satisfies the affinity of n2 only, which has not enough idle resources.
+	checkVictims(t, victimsFnForNodeAffinity(tasks))
+
+	RegisteredStrategyConfigs["removePodsViolatingNodeAffinity"] = map[string]interface{}{"nodeFit": false}
+	checkVictims(t, victimsFnForNodeAffinity(tasks), "p1")
+}
+
+func TestRemovePodsViolatingNodeTaints(t *testing.T) {
+	n1 := buildNode("n1", map[string]string{})
+	n1.Spec.Taints = []v1.Taint{
+		{Key: "dedicated", Value: "infra", Effect: v1.TaintEffectNoSchedule},
+		{Key: "maintenance", Effect: v1.TaintEffectPreferNoSchedule},
+	}
+	n2 := buildNode("n2", map[string]string{})
+	p1 := buildPod("p1", "n1", nil)
+	p2 := buildPod("p2", "n1", nil)
+	p2.Spec.Tolerations = []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "infra", Effect: v1.TaintEffectNoSchedule}}
+	tasks := openSession(t, []*v1.Node{n1, n2}, []*v1.Pod{p1, p2}, nil)
+
+	checkVictims(t, victimsFnForNodeTaints(tasks), "p1")
+
+	RegisteredStrategyConfigs["removePodsViolatingNodeTaints"] = map[string]interface{}{
+		"includePreferNoSchedule": true,
+		"excludedTaints":          []interface{}{"dedicated"},
+	}
+	checkVictims(t, victimsFnForNodeTaints(tasks), "p1", "p2")
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	nodes := []*v1.Node{
+		buildNode("n1", map[string]string{}),
+		buildNode("n2", map[string]string{}),
+	}
+	pods := []*v1.Pod{buildPod("p1", "n1", nil), buildPod("p2", "n1", nil), buildPod("p3", "n1", nil), buildPod("p4", "n2", nil)}
+	tasks := openSession(t, nodes, pods, nil)
+
+	// 2 replicas at most are expected on each node.
+	if victims := victimsFnForDuplicates(tasks); len(victims) != 1 || victims[0].NodeName != "n1" {
+		t.Errorf("expected a replica to be evicted from n1, got %v", victimNames(victims))
+	}
+}
+
+func TestPodTopologySpread(t *testing.T) {
+	nodes := []*v1.Node{
+		buildNode("n1", map[string]string{"zone": "a"}),
+		buildNode("n2", map[string]string{"zone": "a"}),
+		buildNode("n3", map[string]string{"zone": "b"}),
+	}
+	labels := map[string]string{"app": "web"}
+	var pods []*v1.Pod
+	for _, pod := range []*v1.Pod{buildPod("p1", "n1", labels), buildPod("p2", "n1", labels), buildPod("p3", "n2", labels), buildPod("p4", "n2", labels)} {
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+		}}
+		pods = append(pods, pod)
+	}
+	tasks := openSession(t, nodes, pods, nil)
+
+	// Zone a runs 4 pods and zone b none, 2 pods are moved to zone b.
+	victims := victimsFnForTopologySpread(tasks)
+	if len(victims) != 2 {
+		t.Errorf("expected 2 victims, got %v", victimNames(victims))
+	}
+}
+
+func TestPodLifeTime(t *testing.T) {
+	nodes := []*v1.Node{buildNode("n1", map[string]string{})}
+	p1 := buildPod("p1", "n1", nil)
+	p1.Status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	p2 := buildPod("p2", "n1", nil)
+	p2.Status.StartTime = &metav1.Time{Time: time.Now()}
+	tasks := openSession(t, nodes, []*v1.Pod{p1, p2}, nil)
+
+	checkVictims(t, victimsFnForPodLifeTime(tasks))
+
+	RegisteredStrategyConfigs["podLifeTime"] = map[string]interface{}{"maxPodLifeTimeSeconds": 3600}
+	checkVictims(t, victimsFnForPodLifeTime(tasks), "p1")
+}
@@ -16,7 +16,14 @@ limitations under the License.
 
 package rescheduling
 
-import "time"
+import (
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
 
 // lastRescheduleTime records the last execution time.
 var lastRescheduleTime time.Time
@@ -34,3 +41,28 @@ func timeToRun(interval time.Duration) bool {
 	}
 	return false
 }
+
+// parseStrategyConf decodes the params of the strategy into conf, which holds the default values
+func parseStrategyConf(strategy string, conf interface{}) {
+	params, _ := RegisteredStrategyConfigs[strategy].(map[string]interface{})
+	if len(params) == 0 {
+		return
+	}
+	if err := mapstructure.Decode(params, conf); err != nil {
+		klog.Errorf("parameters parse error for %s: %v", strategy, err)
+	}
+}
+
+// fitsOtherNode checks whether the task fits on a schedulable node other than its own one,
+// which satisfies the predicate and has enough idle resources
+func fitsOtherNode(task *api.TaskInfo, predicate func(node *api.NodeInfo) bool) bool {
+	for _, node := range Session.Nodes {
+		if node.Name == task.NodeName || node.Node == nil || node.Node.Spec.Unschedulable {
+			continue
+		}
+		if predicate(node) && task.Resreq.LessEqual(node.Idle, api.Zero) {
+			return true
+		}
+	}
+	return false
+}
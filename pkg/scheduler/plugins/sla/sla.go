@@ -41,6 +41,11 @@ type slaPlugin struct {
 	jobWaitingTime  *time.Duration
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	JobWaitingTime: framework.DurationArgument,
+}
+
 // New function returns sla plugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &slaPlugin{
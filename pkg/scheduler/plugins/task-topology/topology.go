@@ -35,6 +35,11 @@ type taskTopologyPlugin struct {
 	managers map[api.JobID]*JobManager
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	PluginWeight: framework.IntArgument,
+}
+
 // New function returns taskTopologyPlugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &taskTopologyPlugin{
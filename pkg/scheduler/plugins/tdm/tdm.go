@@ -61,6 +61,12 @@ type tdmPlugin struct {
 	evictPeriod time.Duration
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	revocableZoneLabelPrefix + "*": framework.StringArgument,
+	evictPeriodLabel:               framework.DurationArgument,
+}
+
 // New function returns prioritizePlugin object
 func New(args framework.Arguments) framework.Plugin {
 	revocableZone := make(map[string]string)
@@ -18,6 +18,7 @@ package usage
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"volcano.sh/volcano/pkg/scheduler/metrics/source"
@@ -33,6 +34,8 @@ const (
 	// PluginName indicates name of volcano scheduler plugin.
 	PluginName            = "usage"
 	thresholdSection      = "thresholds"
+	scoreThresholdSection = "score.thresholds"
+	weightSection         = "weights"
 	MetricsActiveTime     = 5 * time.Minute
 	NodeUsageCPUExtend    = "the CPU load of the node exceeds the upper limit."
 	NodeUsageMemoryExtend = "the memory load of the node exceeds the upper limit."
@@ -48,9 +51,15 @@ const (
          usage.weight: 5
          cpu.weight: 1
          memory.weight: 1
-         thresholds:
+         weights:     # The weights of the other metrics collected from the metrics source
+           gpu: 2
+         usage.type: p99  # How the usage over the period is summarized: average, max, p95, p99 or ewma
+         thresholds:      # The usage above which the nodes are filtered
            cpu: 80
            mem: 80
+           gpu: 90
+         score.thresholds:  # The usage above which the metric does not contribute to the score of the nodes
+           cpu: 60
 */
 
 const AVG string = "average"
@@ -58,53 +67,125 @@ const AVG string = "average"
 type usagePlugin struct {
 	pluginArguments framework.Arguments
 	usageWeight     int
-	cpuWeight       int
-	memoryWeight    int
 	usageType       string
-	cpuThresholds   float64
-	memThresholds   float64
+	// weights are the weights of the metrics in the score, keyed by metric name.
+	weights map[string]int
+	// thresholds are the usage above which no pod is scheduled on the nodes, keyed by metric name.
+	thresholds map[string]float64
+	// scoreThresholds are the usage above which the metrics do not contribute to the score of the
+	// nodes, as if they were fully used, keyed by metric name.
+	scoreThresholds map[string]float64
 	period          string
 }
 
+// ArgumentSchema declares the arguments accepted by the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	"usage.weight":        framework.IntArgument,
+	"cpu.weight":          framework.IntArgument,
+	"memory.weight":       framework.IntArgument,
+	"usage.type":          framework.StringArgument,
+	weightSection:         framework.MapArgument,
+	thresholdSection:      framework.MapArgument,
+	scoreThresholdSection: framework.MapArgument,
+}
+
 // New function returns usagePlugin object
 func New(args framework.Arguments) framework.Plugin {
 	var plugin = &usagePlugin{
 		pluginArguments: args,
 		usageWeight:     5,
-		cpuWeight:       1,
-		memoryWeight:    1,
 		usageType:       AVG,
-		cpuThresholds:   80,
-		memThresholds:   80,
-		period:          source.NODE_METRICS_PERIOD,
+		weights: map[string]int{
+			source.MetricCPU:    1,
+			source.MetricMemory: 1,
+		},
+		thresholds: map[string]float64{
+			source.MetricCPU:    80,
+			source.MetricMemory: 80,
+		},
+		period: source.NODE_METRICS_PERIOD,
 	}
 	args.GetInt(&plugin.usageWeight, "usage.weight")
-	args.GetInt(&plugin.cpuWeight, "cpu.weight")
-	args.GetInt(&plugin.memoryWeight, "memory.weight")
+	cpuWeight, memoryWeight := plugin.weights[source.MetricCPU], plugin.weights[source.MetricMemory]
+	args.GetInt(&cpuWeight, "cpu.weight")
+	args.GetInt(&memoryWeight, "memory.weight")
+	plugin.weights[source.MetricCPU], plugin.weights[source.MetricMemory] = cpuWeight, memoryWeight
 
-	argsValue, ok := plugin.pluginArguments[thresholdSection]
+	for metric, weight := range metricValues(args, weightSection) {
+		plugin.weights[metric] = int(weight)
+	}
+	for metric, threshold := range metricValues(args, thresholdSection) {
+		plugin.thresholds[metric] = threshold
+	}
+	plugin.scoreThresholds = metricValues(args, scoreThresholdSection)
+
+	if usageType, ok := args["usage.type"].(string); ok {
+		switch usageType {
+		case source.UsageTypeAverage, source.UsageTypeMax, source.UsageTypeP95, source.UsageTypeP99, source.UsageTypeEWMA:
+			plugin.usageType = usageType
+		default:
+			klog.Errorf("Unknown usage type %s, the average usage is used.", usageType)
+		}
+	}
+
+	return plugin
+}
+
+// metricValues returns the values of the metrics of a section of the arguments, "mem" stands for "memory".
+func metricValues(args framework.Arguments, section string) map[string]float64 {
+	values := map[string]float64{}
+	argsValue, ok := args[section]
 	if !ok {
-		klog.Errorf("Failed to obtain thresholds information, usage plugin arguments is %v", plugin.pluginArguments)
-		return plugin
+		if section == thresholdSection {
+			klog.Errorf("Failed to obtain thresholds information, usage plugin arguments is %v", args)
+		}
+		return values
 	}
 
-	thresholdArgs, ok := argsValue.(map[interface{}]interface{})
+	sectionArgs, ok := argsValue.(map[interface{}]interface{})
 	if !ok {
-		klog.Errorf("Failed to convert the thresholds information, thresholds args values is %v", argsValue)
-		return plugin
+		klog.Errorf("Failed to convert the %s information, %s args values is %v", section, section, argsValue)
+		return values
+	}
+	for metricName, metricValue := range sectionArgs {
+		metric, _ := metricName.(string)
+		if metric == "mem" {
+			metric = source.MetricMemory
+		}
+		switch value := metricValue.(type) {
+		case int:
+			values[metric] = float64(value)
+		case float64:
+			values[metric] = value
+		default:
+			klog.Errorf("Failed to convert the %s of metric %s, value is %v", section, metric, metricValue)
+		}
 	}
-	for resourceName, threshold := range thresholdArgs {
-		resource, _ := resourceName.(string)
-		value, _ := threshold.(int)
-		switch resource {
-		case "cpu":
-			plugin.cpuThresholds = float64(value)
-		case "mem":
-			plugin.memThresholds = float64(value)
+	return values
+}
+
+// sortedMetrics returns the names of the metrics, cpu and memory first.
+func sortedMetrics[T any](values map[string]T) []string {
+	var metrics []string
+	for metric := range values {
+		if metric != source.MetricCPU && metric != source.MetricMemory {
+			metrics = append(metrics, metric)
 		}
 	}
+	sort.Strings(metrics)
+	return append([]string{source.MetricCPU, source.MetricMemory}, metrics...)
+}
 
-	return plugin
+// exceededReason returns the reason why a node whose usage of the metric exceeds the threshold is filtered.
+func exceededReason(metric string) string {
+	switch metric {
+	case source.MetricCPU:
+		return NodeUsageCPUExtend
+	case source.MetricMemory:
+		return NodeUsageMemoryExtend
+	default:
+		return fmt.Sprintf("the %s load of the node exceeds the upper limit.", metric)
+	}
 }
 
 func (up *usagePlugin) Name() string {
@@ -119,11 +200,14 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 
 	if klog.V(4).Enabled() {
 		for node, nodeInfo := range ssn.Nodes {
-			klog.V(4).Infof("node:%v, cpu usage:%v, mem usage:%v, metrics time is %v",
-				node, nodeInfo.ResourceUsage.CPUUsageAvg, nodeInfo.ResourceUsage.MEMUsageAvg, nodeInfo.ResourceUsage.MetricsTime)
+			klog.V(4).Infof("node:%v, cpu usage:%v, mem usage:%v, other usage:%v, metrics time is %v",
+				node, nodeInfo.ResourceUsage.CPUUsageAvg, nodeInfo.ResourceUsage.MEMUsageAvg, nodeInfo.ResourceUsage.MetricsAvg, nodeInfo.ResourceUsage.MetricsTime)
 		}
 	}
 
+	thresholdMetrics := sortedMetrics(up.thresholds)
+	weightMetrics := sortedMetrics(up.weights)
+
 	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) ([]*api.Status, error) {
 		predicateStatus := make([]*api.Status, 0)
 		usageStatus := &api.Status{}
@@ -138,20 +222,21 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 			return predicateStatus, nil
 		}
 
-		klog.V(4).Infof("predicateFn cpuUsageAvg:%v,predicateFn memUsageAvg:%v", up.cpuThresholds, up.memThresholds)
-		if node.ResourceUsage.CPUUsageAvg[up.period] > up.cpuThresholds {
-			klog.V(3).Infof("Node %s cpu usage %f exceeds the threshold %f", node.Name, node.ResourceUsage.CPUUsageAvg[up.period], up.cpuThresholds)
-			usageStatus.Code = api.UnschedulableAndUnresolvable
-			usageStatus.Reason = NodeUsageCPUExtend
-			predicateStatus = append(predicateStatus, usageStatus)
-			return predicateStatus, fmt.Errorf("plugin %s predicates failed, because of %s", up.Name(), NodeUsageCPUExtend)
-		}
-		if node.ResourceUsage.MEMUsageAvg[up.period] > up.memThresholds {
-			klog.V(3).Infof("Node %s mem usage %f exceeds the threshold %f", node.Name, node.ResourceUsage.MEMUsageAvg[up.period], up.memThresholds)
-			usageStatus.Code = api.UnschedulableAndUnresolvable
-			usageStatus.Reason = NodeUsageMemoryExtend
-			predicateStatus = append(predicateStatus, usageStatus)
-			return predicateStatus, fmt.Errorf("plugin %s predicates failed, because of %s", up.Name(), NodeUsageMemoryExtend)
+		klog.V(4).Infof("predicateFn %s thresholds:%v", up.usageType, up.thresholds)
+		for _, metric := range thresholdMetrics {
+			threshold, found := up.thresholds[metric]
+			if !found {
+				continue
+			}
+			usage, _ := node.ResourceUsage.UsageOf(up.usageType, metric, up.period)
+			if usage > threshold {
+				klog.V(3).Infof("Node %s %s %s usage %f exceeds the threshold %f", node.Name, up.usageType, metric, usage, threshold)
+				reason := exceededReason(metric)
+				usageStatus.Code = api.UnschedulableAndUnresolvable
+				usageStatus.Reason = reason
+				predicateStatus = append(predicateStatus, usageStatus)
+				return predicateStatus, fmt.Errorf("plugin %s predicates failed, because of %s", up.Name(), reason)
+			}
 		}
 
 		klog.V(4).Infof("Usage plugin filter for task %s/%s on node %s pass.", task.Namespace, task.Name, node.Name)
@@ -167,20 +252,28 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 			return 0, nil
 		}
 
-		cpuUsage, exist := node.ResourceUsage.CPUUsageAvg[up.period]
-		klog.V(4).Infof("Node %s cpu usage is %f.", node.Name, cpuUsage)
-		if !exist {
-			return 0, nil
+		totalWeight := 0
+		for _, metric := range weightMetrics {
+			weight := up.weights[metric]
+			if weight == 0 {
+				continue
+			}
+			usage, exist := node.ResourceUsage.UsageOf(up.usageType, metric, up.period)
+			klog.V(4).Infof("Node %s %s %s usage is %f.", node.Name, up.usageType, metric, usage)
+			if !exist {
+				return 0, nil
+			}
+			if threshold, found := up.scoreThresholds[metric]; found && usage > threshold {
+				klog.V(4).Infof("Node %s %s usage %f exceeds the score threshold %f.", node.Name, metric, usage, threshold)
+				usage = 100
+			}
+			score += (100 - usage) / 100 * float64(weight)
+			totalWeight += weight
 		}
-		cpuScore := (100 - cpuUsage) / 100 * float64(up.cpuWeight)
-
-		memoryUsage, exist := node.ResourceUsage.MEMUsageAvg[up.period]
-		klog.V(4).Infof("Node %s memory usage is %f.", node.Name, memoryUsage)
-		if !exist {
+		if totalWeight == 0 {
 			return 0, nil
 		}
-		memoryScore := (100 - memoryUsage) / 100 * float64(up.memoryWeight)
-		score = (cpuScore + memoryScore) / float64(up.cpuWeight+up.memoryWeight)
+		score /= float64(totalWeight)
 		score *= float64(k8sFramework.MaxNodeScore * int64(up.usageWeight))
 		klog.V(4).Infof("Node %s score for task %s is %f.", node.Name, task.Name, score)
 		return score, nil
@@ -558,3 +558,138 @@ func TestUsage_nodeOrderFn(t *testing.T) {
 		})
 	}
 }
+
+func TestUsage_otherMetrics(t *testing.T) {
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string)))
+
+	timeNow := time.Now()
+	for node, gpuUsage := range map[string]float64{"n1": 95, "n2": 20} {
+		nodeUsage := buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 50}, map[string]float64{source.NODE_METRICS_PERIOD: 50}, timeNow)
+		nodeUsage.MetricsAvg = map[string]map[string]float64{"gpu": {source.NODE_METRICS_PERIOD: gpuUsage}}
+		sc.Nodes[node].ResourceUsage = nodeUsage
+	}
+
+	trueValue := true
+	ssn := framework.OpenSession(sc, []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+					Arguments: framework.Arguments{
+						"weights": map[interface{}]interface{}{
+							"gpu": 2,
+						},
+						"thresholds": map[interface{}]interface{}{
+							"cpu": 80,
+							"mem": 80,
+							"gpu": 90.5,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	defer framework.CloseSession(ssn)
+
+	var task *api.TaskInfo
+	for _, jobTask := range ssn.Jobs[api.JobID("c1/pg1")].Tasks {
+		task = jobTask
+	}
+
+	status, err := ssn.PredicateFn(task, ssn.Nodes["n1"])
+	if reason := "the gpu load of the node exceeds the upper limit."; err == nil || len(status) != 1 || status[0].Reason != reason {
+		t.Errorf("expected task not to fit on n1 as %s, got %v, %v", reason, status, err)
+	}
+	if _, err := ssn.PredicateFn(task, ssn.Nodes["n2"]); err != nil {
+		t.Errorf("expected task to fit on n2, got error %v", err)
+	}
+
+	// The score is (cpu 0.5 * 1 + memory 0.5 * 1 + gpu free ratio * 2) / 4 * 100 * 5.
+	for node, expectScore := range map[string]float64{"n1": 137.5, "n2": 325} {
+		score, err := ssn.NodeOrderFn(task, ssn.Nodes[node])
+		if err != nil || math.Abs(expectScore-score) > eps {
+			t.Errorf("expected score %v on node %s, got %v, %v", expectScore, node, score, err)
+		}
+	}
+}
+
+func TestUsage_usageType(t *testing.T) {
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string)))
+
+	// All the nodes have the same average usage, n1 has cpu spikes, n2 has memory spikes.
+	timeNow := time.Now()
+	for node, maxUsage := range map[string]map[string]float64{
+		"n1": {"cpu": 95, "memory": 40},
+		"n2": {"cpu": 40, "memory": 70},
+		"n3": {"cpu": 40, "memory": 40},
+	} {
+		sc.AddOrUpdateNode(util.BuildNode(node, api.BuildResourceList("4", "8Gi"), make(map[string]string)))
+		nodeUsage := buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 20}, map[string]float64{source.NODE_METRICS_PERIOD: 20}, timeNow)
+		nodeUsage.Usage = map[string]map[string]map[string]float64{
+			source.UsageTypeMax: {
+				"cpu":    {source.NODE_METRICS_PERIOD: maxUsage["cpu"]},
+				"memory": {source.NODE_METRICS_PERIOD: maxUsage["memory"]},
+			},
+		}
+		sc.Nodes[node].ResourceUsage = nodeUsage
+	}
+
+	trueValue := true
+	ssn := framework.OpenSession(sc, []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+					Arguments: framework.Arguments{
+						"usage.type": "max",
+						"thresholds": map[interface{}]interface{}{
+							"cpu": 90,
+							"mem": 90,
+						},
+						"score.thresholds": map[interface{}]interface{}{
+							"mem": 60,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	defer framework.CloseSession(ssn)
+
+	var task *api.TaskInfo
+	for _, jobTask := range ssn.Jobs[api.JobID("c1/pg1")].Tasks {
+		task = jobTask
+	}
+
+	for node, fit := range map[string]bool{"n1": false, "n2": true, "n3": true} {
+		if _, err := ssn.PredicateFn(task, ssn.Nodes[node]); (err == nil) != fit {
+			t.Errorf("expected task to fit on %s: %v, got error %v", node, fit, err)
+		}
+	}
+
+	// The memory of n2 exceeds the score threshold and is scored as fully used.
+	for node, expectScore := range map[string]float64{"n2": 150, "n3": 300} {
+		score, err := ssn.NodeOrderFn(task, ssn.Nodes[node])
+		if err != nil || math.Abs(expectScore-score) > eps {
+			t.Errorf("expected score %v on node %s, got %v, %v", expectScore, node, score, err)
+		}
+	}
+}
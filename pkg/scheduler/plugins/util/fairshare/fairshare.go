@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// Enabled is the argument to take the decayed usage of the queues into account
+	Enabled = "fairShare.enabled"
+	// HalfLife is the argument of the duration after which the usage counts for half
+	HalfLife = "fairShare.halfLife"
+	// Weight is the argument of the weight of the decayed usage against the current share
+	Weight = "fairShare.weight"
+	// ConfigMap is the argument of the <namespace>/<name> config map the decayed usage is persisted in
+	ConfigMap = "fairShare.configMap"
+	// PersistInterval is the argument of the interval the decayed usage is persisted at
+	PersistInterval = "fairShare.persistInterval"
+
+	defaultHalfLife        = 24 * time.Hour
+	defaultWeight          = 1.0
+	defaultPersistInterval = time.Minute
+
+	// usageKey is the key of the decayed usage in the config map
+	usageKey = "usage"
+)
+
+// ArgumentSchema declares the fair share arguments accepted by the plugins.
+var ArgumentSchema = framework.ArgumentSchema{
+	Enabled:         framework.BoolArgument,
+	HalfLife:        framework.DurationArgument,
+	Weight:          framework.FloatArgument,
+	ConfigMap:       framework.StringArgument,
+	PersistInterval: framework.DurationArgument,
+}
+
+// Config is the fair share configuration of a plugin.
+type Config struct {
+	HalfLife        time.Duration
+	Weight          float64
+	Namespace       string
+	Name            string
+	PersistInterval time.Duration
+}
+
+// NewConfig returns the fair share configuration in the arguments, nil if fair share is disabled.
+func NewConfig(arguments framework.Arguments) *Config {
+	enabled := false
+	arguments.GetBool(&enabled, Enabled)
+	if !enabled {
+		return nil
+	}
+
+	config := &Config{
+		HalfLife:        defaultHalfLife,
+		Weight:          defaultWeight,
+		PersistInterval: defaultPersistInterval,
+	}
+	arguments.GetFloat64(&config.Weight, Weight)
+	if halfLife, _ := arguments[HalfLife].(string); halfLife != "" {
+		if duration, err := time.ParseDuration(halfLife); err == nil && duration > 0 {
+			config.HalfLife = duration
+		}
+	}
+	if persistInterval, _ := arguments[PersistInterval].(string); persistInterval != "" {
+		if duration, err := time.ParseDuration(persistInterval); err == nil {
+			config.PersistInterval = duration
+		}
+	}
+	if configMap, _ := arguments[ConfigMap].(string); configMap != "" {
+		if namespace, name, found := strings.Cut(configMap, "/"); found {
+			config.Namespace, config.Name = namespace, name
+		} else {
+			klog.Warningf("Invalid fair share config map %q, expected <namespace>/<name>, the usage is not persisted.", configMap)
+		}
+	}
+	return config
+}
+
+// usage is the decayed usage, in resource-seconds by resource name.
+type usage map[v1.ResourceName]float64
+
+// record is the decayed usage persisted in the config map.
+type record struct {
+	LastUpdate time.Time        `json:"lastUpdate"`
+	Total      usage            `json:"total"`
+	Queues     map[string]usage `json:"queues"`
+}
+
+// History is the decayed usage of the queues. The usage is accumulated across
+// sessions, and across restarts of the scheduler if a config map is configured.
+type History struct {
+	mutex       sync.Mutex
+	record      record
+	lastSession types.UID
+	lastPersist time.Time
+	loaded      bool
+}
+
+// The histories are kept across sessions by config map, the plugins configured
+// with the same config map share the history.
+var (
+	historyMutex sync.Mutex
+	histories    = map[string]*History{}
+)
+
+// GetHistory returns the history of the configuration.
+func GetHistory(config *Config) *History {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+
+	key := config.Namespace + "/" + config.Name
+	h, found := histories[key]
+	if !found {
+		h = &History{record: record{Total: usage{}, Queues: map[string]usage{}}}
+		histories[key] = h
+	}
+	return h
+}
+
+// Update accumulates the resources allocated to the queues in the session since the last update.
+func (h *History) Update(ssn *framework.Session, config *Config) {
+	allocated := map[api.QueueID]*api.Resource{}
+	for _, job := range ssn.Jobs {
+		if _, found := allocated[job.Queue]; !found {
+			allocated[job.Queue] = api.EmptyResource()
+		}
+		for status, tasks := range job.TaskStatusIndex {
+			if api.AllocatedStatus(status) {
+				for _, t := range tasks {
+					allocated[job.Queue].Add(t.Resreq)
+				}
+			}
+		}
+	}
+	h.update(ssn.KubeClient(), config, ssn.UID, allocated, ssn.TotalResource, time.Now())
+}
+
+func (h *History) update(client kubernetes.Interface, config *Config, session types.UID,
+	allocated map[api.QueueID]*api.Resource, total *api.Resource, now time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// The plugins sharing the history update it once per session.
+	if session == h.lastSession {
+		return
+	}
+	h.lastSession = session
+
+	if !h.loaded && config.Name != "" {
+		h.load(client, config)
+	}
+	h.loaded = true
+
+	if !h.record.LastUpdate.IsZero() && now.After(h.record.LastUpdate) {
+		elapsed := now.Sub(h.record.LastUpdate).Seconds()
+		decay := math.Pow(0.5, elapsed/config.HalfLife.Seconds())
+		h.record.Total.accumulate(total, decay, elapsed)
+		for queue, u := range h.record.Queues {
+			if _, found := allocated[api.QueueID(queue)]; !found {
+				u.accumulate(api.EmptyResource(), decay, elapsed)
+			}
+		}
+		for queue, resource := range allocated {
+			u, found := h.record.Queues[string(queue)]
+			if !found {
+				u = usage{}
+				h.record.Queues[string(queue)] = u
+			}
+			u.accumulate(resource, decay, elapsed)
+		}
+	}
+	h.record.LastUpdate = now
+
+	for queue := range h.record.Queues {
+		metrics.UpdateQueueDecayedUsage(queue, h.share(queue))
+	}
+
+	if config.Name != "" && now.Sub(h.lastPersist) >= config.PersistInterval {
+		h.persist(client, config)
+		h.lastPersist = now
+	}
+}
+
+// accumulate decays the usage and adds the resources used for the elapsed seconds.
+func (u usage) accumulate(resource *api.Resource, decay, elapsed float64) {
+	for rn := range u {
+		u[rn] *= decay
+	}
+	for _, rn := range resource.ResourceNames() {
+		u[rn] += resource.Get(rn) * elapsed
+	}
+}
+
+// Share returns the decayed usage of the queue, that is the dominant share of
+// the cluster it used, weighted by recency.
+func (h *History) Share(queue api.QueueID) float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.share(string(queue))
+}
+
+func (h *History) share(queue string) float64 {
+	res := float64(0)
+	for rn, used := range h.record.Queues[queue] {
+		total := h.record.Total[rn]
+		if total <= 0 {
+			continue
+		}
+		if share := used / total; share > res {
+			res = share
+		}
+	}
+	return res
+}
+
+func (h *History) load(client kubernetes.Interface, config *Config) {
+	cm, err := client.CoreV1().ConfigMaps(config.Namespace).Get(context.TODO(), config.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get fair share config map <%s/%s>: %v", config.Namespace, config.Name, err)
+		}
+		return
+	}
+	r := record{}
+	if err := json.Unmarshal([]byte(cm.Data[usageKey]), &r); err != nil {
+		klog.Errorf("Failed to parse fair share config map <%s/%s>: %v", config.Namespace, config.Name, err)
+		return
+	}
+	if r.Total == nil {
+		r.Total = usage{}
+	}
+	if r.Queues == nil {
+		r.Queues = map[string]usage{}
+	}
+	for queue, u := range r.Queues {
+		if u == nil {
+			r.Queues[queue] = usage{}
+		}
+	}
+	h.record = r
+	klog.V(3).Infof("Loaded the decayed usage of %d queues from config map <%s/%s>.", len(r.Queues), config.Namespace, config.Name)
+}
+
+func (h *History) persist(client kubernetes.Interface, config *Config) {
+	data, err := json.Marshal(&h.record)
+	if err != nil {
+		klog.Errorf("Failed to encode the decayed usage of the queues: %v", err)
+		return
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(config.Namespace)
+	cm, err := configMaps.Get(context.TODO(), config.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: config.Namespace, Name: config.Name},
+			Data:       map[string]string{usageKey: string(data)},
+		}
+		_, err = configMaps.Create(context.TODO(), cm, metav1.CreateOptions{})
+	} else if err == nil {
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[usageKey] = string(data)
+		_, err = configMaps.Update(context.TODO(), cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		klog.Errorf("Failed to persist the decayed usage in config map <%s/%s>: %v", config.Namespace, config.Name, err)
+	}
+}
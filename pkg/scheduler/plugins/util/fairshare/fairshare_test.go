@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func TestNewConfig(t *testing.T) {
+	if config := NewConfig(framework.Arguments{}); config != nil {
+		t.Errorf("expected fair share to be disabled by default, got %+v", config)
+	}
+
+	config := NewConfig(framework.Arguments{
+		Enabled:   true,
+		HalfLife:  "1h",
+		Weight:    2,
+		ConfigMap: "volcano-system/fair-share",
+	})
+	if config == nil || config.HalfLife != time.Hour || config.Weight != 2 ||
+		config.Namespace != "volcano-system" || config.Name != "fair-share" || config.PersistInterval != defaultPersistInterval {
+		t.Errorf("unexpected config %+v", config)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	config := &Config{HalfLife: time.Hour, Weight: 1, Namespace: "volcano-system", Name: "fair-share"}
+	total := api.NewResource(api.BuildResourceList("10", "10Gi"))
+	now := time.Now()
+
+	h := &History{record: record{Total: usage{}, Queues: map[string]usage{}}}
+	// q1 uses half of the cluster for an hour, then q2 uses half of the cluster for an hour.
+	h.update(client, config, "s1", map[api.QueueID]*api.Resource{"q1": api.EmptyResource()}, total, now)
+	h.update(client, config, "s2", map[api.QueueID]*api.Resource{"q1": api.NewResource(api.BuildResourceList("5", "1Gi"))}, total, now.Add(time.Hour))
+	// Updates in the same session are ignored.
+	h.update(client, config, "s2", map[api.QueueID]*api.Resource{"q1": total}, total, now.Add(2*time.Hour))
+	h.update(client, config, "s3", map[api.QueueID]*api.Resource{"q2": api.NewResource(api.BuildResourceList("5", "1Gi"))}, total, now.Add(2*time.Hour))
+
+	// The usage of q1 decayed by half, the total usage is 3600*10*(1/2+1).
+	if share := h.Share("q1"); math.Abs(share-1.0/6) > 1e-9 {
+		t.Errorf("expected the decayed usage of q1 to be 1/6, got %v", share)
+	}
+	if share := h.Share("q2"); math.Abs(share-1.0/3) > 1e-9 {
+		t.Errorf("expected the decayed usage of q2 to be 1/3, got %v", share)
+	}
+
+	cm, err := client.CoreV1().ConfigMaps("volcano-system").Get(context.TODO(), "fair-share", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the usage to be persisted: %v", err)
+	}
+	if len(cm.Data[usageKey]) == 0 {
+		t.Fatalf("expected the usage to be persisted in key %s, got %v", usageKey, cm.Data)
+	}
+
+	// A new history, e.g. after a restart of the scheduler, is loaded from the config map.
+	restarted := &History{record: record{Total: usage{}, Queues: map[string]usage{}}}
+	restarted.update(client, config, "s4", map[api.QueueID]*api.Resource{}, total, now.Add(2*time.Hour))
+	if share := restarted.Share("q1"); math.Abs(share-1.0/6) > 1e-9 {
+		t.Errorf("expected the decayed usage of q1 to be loaded, got %v", share)
+	}
+}
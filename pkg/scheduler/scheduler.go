@@ -18,6 +18,7 @@ package scheduler
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
@@ -35,6 +37,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
 )
 
 // Scheduler represents a "Volcano Scheduler".
@@ -47,9 +50,17 @@ type Scheduler struct {
 	schedulePeriod time.Duration
 	once           sync.Once
 
+	// schedulerConfResource is the name of the SchedulerConfiguration resource
+	// used instead of schedulerConf, schedulerConfGeneration is its generation
+	// handled last.
+	schedulerConfResource   string
+	schedulerConfGeneration int64
+	dynamicClient           dynamic.Interface
+
 	mutex          sync.Mutex
 	actions        []framework.Action
 	plugins        []conf.Tier
+	profiles       []conf.Profile
 	configurations []conf.Configuration
 	metricsConf    map[string]string
 	dumper         schedcache.Dumper
@@ -58,7 +69,7 @@ type Scheduler struct {
 // NewScheduler returns a Scheduler
 func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, error) {
 	var watcher filewatcher.FileWatcher
-	if opt.SchedulerConf != "" {
+	if opt.SchedulerConf != "" && opt.SchedulerConfResource == "" {
 		var err error
 		path := filepath.Dir(opt.SchedulerConf)
 		watcher, err = filewatcher.NewFileWatcher(path)
@@ -75,6 +86,17 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 		schedulePeriod: opt.SchedulePeriod,
 		dumper:         schedcache.Dumper{Cache: cache, RootDir: opt.CacheDumpFileDir},
 	}
+	if opt.SchedulerConfResource != "" {
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating dynamic client for scheduler configuration resource %s: %v", opt.SchedulerConfResource, err)
+		}
+		scheduler.schedulerConfResource = opt.SchedulerConfResource
+		scheduler.dynamicClient = dynamicClient
+	}
+	if opt.EnableDebugHandler {
+		scheduler.dumper.Sessions = schedcache.NewSessionHistory(opt.DebugSessionHistory)
+	}
 
 	return scheduler, nil
 }
@@ -83,7 +105,11 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 // initializes the cache, and begins the scheduling process.
 func (pc *Scheduler) Run(stopCh <-chan struct{}) {
 	pc.loadSchedulerConf()
-	go pc.watchSchedulerConf(stopCh)
+	if len(pc.schedulerConfResource) != 0 {
+		pc.watchSchedulerConfResource(stopCh)
+	} else {
+		go pc.watchSchedulerConf(stopCh)
+	}
 	// Start cache for policy.
 	pc.cache.SetMetricsConf(pc.metricsConf)
 	pc.cache.Run(stopCh)
@@ -106,6 +132,7 @@ func (pc *Scheduler) runOnce() {
 	pc.mutex.Lock()
 	actions := pc.actions
 	plugins := pc.plugins
+	profiles := pc.profiles
 	configurations := pc.configurations
 	pc.mutex.Unlock()
 
@@ -115,19 +142,45 @@ func (pc *Scheduler) runOnce() {
 		conf.EnabledActionMap[action.Name()] = true
 	}
 
-	ssn := framework.OpenSession(pc.cache, plugins, configurations)
+	ssn := framework.OpenSessionWithProfiles(pc.cache, plugins, profiles, configurations)
+	if options.ServerOpts.EnableSchedulingTrace {
+		ssn.EnableTrace()
+	}
 	defer func() {
 		framework.CloseSession(ssn)
+		if pc.dumper.Sessions != nil {
+			pc.dumper.Sessions.Add(ssn.Info())
+		}
 		metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
 	}()
 
 	for _, action := range actions {
 		actionStartTime := time.Now()
+		ssn.SetCurrentAction(action.Name())
+		ssn.StartSpan("Execute", tracing.ActionKey.String(action.Name()))
 		action.Execute(ssn)
+		ssn.EndSpan()
 		metrics.UpdateActionDuration(action.Name(), metrics.Duration(actionStartTime))
 	}
 }
 
+// DebugHandler returns the handler serving the scheduler cache and the last
+// session decisions, requests must carry the token in tokenFile.
+func (pc *Scheduler) DebugHandler(tokenFile string) (http.Handler, error) {
+	if len(tokenFile) == 0 {
+		return nil, fmt.Errorf("a token file is required to serve the debug handler")
+	}
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read debug token file %s: %v", tokenFile, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		return nil, fmt.Errorf("debug token file %s is empty", tokenFile)
+	}
+	return pc.dumper.DebugHandler(token), nil
+}
+
 func (pc *Scheduler) loadSchedulerConf() {
 	klog.V(4).Infof("Start loadSchedulerConf ...")
 	defer func() {
@@ -137,13 +190,18 @@ func (pc *Scheduler) loadSchedulerConf() {
 
 	var err error
 	pc.once.Do(func() {
-		pc.actions, pc.plugins, pc.configurations, pc.metricsConf, err = UnmarshalSchedulerConf(DefaultSchedulerConf)
+		pc.actions, pc.plugins, pc.profiles, pc.configurations, pc.metricsConf, err = UnmarshalSchedulerConf(DefaultSchedulerConf)
 		if err != nil {
 			klog.Errorf("unmarshal Scheduler config %s failed: %v", DefaultSchedulerConf, err)
 			panic("invalid default configuration")
 		}
 	})
 
+	if len(pc.schedulerConfResource) != 0 {
+		// The configuration resource is applied by watchSchedulerConfResource.
+		return
+	}
+
 	var config string
 	if len(pc.schedulerConf) != 0 {
 		confData, err := os.ReadFile(pc.schedulerConf)
@@ -155,7 +213,7 @@ func (pc *Scheduler) loadSchedulerConf() {
 		config = strings.TrimSpace(string(confData))
 	}
 
-	actions, plugins, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
+	actions, plugins, profiles, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
 	if err != nil {
 		klog.Errorf("Scheduler config %s is invalid: %v", config, err)
 		return
@@ -164,6 +222,7 @@ func (pc *Scheduler) loadSchedulerConf() {
 	pc.mutex.Lock()
 	pc.actions = actions
 	pc.plugins = plugins
+	pc.profiles = profiles
 	pc.configurations = configurations
 	pc.metricsConf = metricsConf
 	pc.mutex.Unlock()
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+)
+
+// Simulate runs one scheduling cycle against the snapshot in opt.SimulateSnapshot
+// with the scheduler configuration in opt.SchedulerConf, and writes the bindings,
+// evictions and PodGroup status the scheduler would have produced as json.
+func Simulate(opt *options.ServerOption) error {
+	config := DefaultSchedulerConf
+	if len(opt.SchedulerConf) != 0 {
+		confData, err := os.ReadFile(opt.SchedulerConf)
+		if err != nil {
+			return fmt.Errorf("failed to read the Scheduler config in '%s': %v", opt.SchedulerConf, err)
+		}
+		config = strings.TrimSpace(string(confData))
+	}
+
+	actions, plugins, profiles, configurations, _, err := UnmarshalSchedulerConf(config)
+	if err != nil {
+		return fmt.Errorf("scheduler config %s is invalid: %v", opt.SchedulerConf, err)
+	}
+
+	snapshot, err := schedcache.LoadSnapshotFile(opt.SimulateSnapshot)
+	if err != nil {
+		return err
+	}
+
+	result, err := simulator.New(actions, plugins, configurations).WithProfiles(profiles).Run(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to simulate scheduling: %v", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if len(opt.SimulateOutput) != 0 {
+		file, err := os.OpenFile(opt.SimulateOutput, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to create simulation output %s: %v", opt.SimulateOutput, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
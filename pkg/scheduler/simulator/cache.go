@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	schedulingscheme "volcano.sh/apis/pkg/apis/scheduling/scheme"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+const simulatorSchedulerName = "volcano"
+
+// simulatedCache is a scheduler cache rebuilt from a snapshot. It keeps the
+// real cache bookkeeping for snapshots and volumes, but records bindings,
+// evictions and PodGroup updates instead of sending them to a cluster.
+type simulatedCache struct {
+	*cache.SchedulerCache
+
+	mutex     sync.Mutex
+	bindings  map[string]string
+	evictions []Eviction
+	podGroups map[string]*PodGroupResult
+}
+
+var _ cache.Cache = &simulatedCache{}
+
+// newSimulatedCache replays the objects referenced by the snapshot into a mock
+// scheduler cache, so that the cache derives jobs, tasks and node usage itself.
+func newSimulatedCache(snapshot *api.ClusterInfo) (*simulatedCache, error) {
+	sc := cache.NewCustomMockSchedulerCache(simulatorSchedulerName, nil, nil, nil, nil, nil, &record.FakeRecorder{})
+
+	for _, node := range snapshot.Nodes {
+		if node.Node == nil {
+			continue
+		}
+		if err := sc.AddOrUpdateNode(node.Node.DeepCopy()); err != nil {
+			return nil, fmt.Errorf("failed to add node <%s>: %v", node.Name, err)
+		}
+	}
+
+	for _, queue := range snapshot.Queues {
+		if queue.Queue == nil {
+			continue
+		}
+		q := &vcv1beta1.Queue{}
+		if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert queue <%s>: %v", queue.Name, err)
+		}
+		sc.AddQueueV1beta1(q)
+	}
+
+	priorityClasses := map[string]int32{}
+	for _, job := range snapshot.Jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		pg := &vcv1beta1.PodGroup{}
+		if err := schedulingscheme.Scheme.Convert(&job.PodGroup.PodGroup, pg, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert podgroup of job <%s>: %v", job.UID, err)
+		}
+		sc.AddPodGroupV1beta1(pg)
+		if name := job.PodGroup.Spec.PriorityClassName; name != "" {
+			priorityClasses[name] = job.Priority
+		}
+	}
+
+	// The snapshot only keeps the resolved job priority, so recreate the
+	// priority classes from it to get the same ordering back.
+	for name, value := range priorityClasses {
+		sc.AddPriorityClass(&schedulingv1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Value:      value,
+		})
+	}
+
+	for _, pod := range snapshotPods(snapshot) {
+		sc.AddPod(pod)
+	}
+
+	// Node usage and CSI node status come from metrics and CSINode objects
+	// which are not part of the snapshot, take them over as they are.
+	for name, node := range snapshot.Nodes {
+		if cached, found := sc.Nodes[name]; found && node.ResourceUsage != nil {
+			cached.ResourceUsage = node.ResourceUsage.DeepCopy()
+		}
+	}
+	for name, status := range snapshot.CSINodesStatus {
+		sc.CSINodesStatus[name] = status.Clone()
+	}
+
+	for _, ns := range snapshot.NamespaceInfo {
+		for name, status := range ns.QuotaStatus {
+			sc.AddResourceQuota(&v1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: string(ns.Name)},
+				Status:     status,
+			})
+		}
+	}
+
+	return &simulatedCache{
+		SchedulerCache: sc,
+		bindings:       map[string]string{},
+		podGroups:      map[string]*PodGroupResult{},
+	}, nil
+}
+
+// snapshotPods collects the pods of all jobs and nodes in the snapshot, nodes
+// also hold pods that are not managed by volcano but still consume resources.
+func snapshotPods(snapshot *api.ClusterInfo) []*v1.Pod {
+	seen := map[api.TaskID]bool{}
+	var pods []*v1.Pod
+
+	add := func(tasks map[api.TaskID]*api.TaskInfo) {
+		for uid, task := range tasks {
+			if task.Pod == nil || seen[uid] {
+				continue
+			}
+			seen[uid] = true
+			pods = append(pods, task.Pod.DeepCopy())
+		}
+	}
+
+	for _, job := range snapshot.Jobs {
+		add(job.Tasks)
+	}
+	for _, node := range snapshot.Nodes {
+		add(node.Tasks)
+	}
+
+	return pods
+}
+
+// AddBindTask records the binding instead of binding the pod.
+func (sc *simulatedCache) AddBindTask(task *api.TaskInfo) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.bindings[taskKey(task)] = task.NodeName
+	klog.V(3).Infof("Simulated binding of task <%s> to node <%s>", taskKey(task), task.NodeName)
+	return nil
+}
+
+// Evict records the eviction instead of evicting the pod.
+func (sc *simulatedCache) Evict(task *api.TaskInfo, reason string) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.evictions = append(sc.evictions, Eviction{
+		Task:   taskKey(task),
+		Node:   task.NodeName,
+		Reason: reason,
+	})
+	klog.V(3).Infof("Simulated eviction of task <%s> on node <%s>: %s", taskKey(task), task.NodeName, reason)
+	return nil
+}
+
+// UpdateJobStatus records the PodGroup status computed when the session closes.
+func (sc *simulatedCache) UpdateJobStatus(job *api.JobInfo, updatePG bool) (*api.JobInfo, error) {
+	if job.PodGroup == nil {
+		return job, nil
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	status := job.PodGroup.Status.DeepCopy()
+	sc.podGroups[string(job.UID)] = &PodGroupResult{
+		Phase:      status.Phase,
+		Conditions: status.Conditions,
+		Updated:    updatePG,
+	}
+	return job, nil
+}
+
+// UpdateQueueStatus is a no-op, queue status is not part of the simulation result.
+func (sc *simulatedCache) UpdateQueueStatus(queue *api.QueueInfo) error {
+	return nil
+}
+
+// BindPodGroup is a no-op, there is no silo cluster to bind to.
+func (sc *simulatedCache) BindPodGroup(job *api.JobInfo, cluster string) error {
+	return nil
+}
+
+// result returns a copy of everything recorded so far.
+func (sc *simulatedCache) result() *Result {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	result := &Result{
+		Bindings:  make(map[string]string, len(sc.bindings)),
+		Evictions: append([]Eviction{}, sc.evictions...),
+		PodGroups: make(map[string]PodGroupResult, len(sc.podGroups)),
+	}
+	for task, node := range sc.bindings {
+		result.Bindings[task] = node
+	}
+	for job, pg := range sc.podGroups {
+		result.PodGroups[job] = *pg
+	}
+	return result
+}
+
+func taskKey(task *api.TaskInfo) string {
+	return fmt.Sprintf("%s/%s", task.Namespace, task.Name)
+}
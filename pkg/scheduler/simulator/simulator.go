@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator runs the scheduler actions and plugins against a cluster
+// snapshot offline, e.g. to compare scheduler configurations or to find out
+// why a job does not fit, without touching a real cluster.
+package simulator
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// Result is what the scheduler would have done in a simulated session.
+type Result struct {
+	// Bindings maps namespace/name of each bound task to the selected node.
+	Bindings map[string]string `json:"bindings"`
+	// Evictions lists the tasks evicted by preempt, reclaim or shuffle.
+	Evictions []Eviction `json:"evictions"`
+	// PodGroups holds the PodGroup status of each job at session close.
+	PodGroups map[string]PodGroupResult `json:"podGroups"`
+	// Traces holds the predicate verdict and score of each plugin per node for all tasks.
+	Traces []*api.TaskTrace `json:"traces,omitempty"`
+}
+
+// Eviction is a task evicted during the simulation.
+type Eviction struct {
+	Task   string `json:"task"`
+	Node   string `json:"node"`
+	Reason string `json:"reason"`
+}
+
+// PodGroupResult is the PodGroup status computed for a job.
+type PodGroupResult struct {
+	Phase      scheduling.PodGroupPhase       `json:"phase"`
+	Conditions []scheduling.PodGroupCondition `json:"conditions,omitempty"`
+	// Updated is true if the scheduler would have written the status back.
+	Updated bool `json:"updated"`
+}
+
+// Simulator replays snapshots through a fixed scheduler configuration.
+type Simulator struct {
+	actions        []framework.Action
+	tiers          []conf.Tier
+	profiles       []conf.Profile
+	configurations []conf.Configuration
+}
+
+// New returns a Simulator for the given actions, plugin tiers and action configurations,
+// as returned by UnmarshalSchedulerConf.
+func New(actions []framework.Action, tiers []conf.Tier, configurations []conf.Configuration) *Simulator {
+	// The actions read the server options, which are only set from the flags of the scheduler.
+	if options.ServerOpts == nil {
+		options.ServerOpts = options.NewServerOption()
+	}
+	return &Simulator{
+		actions:        actions,
+		tiers:          tiers,
+		configurations: configurations,
+	}
+}
+
+// WithProfiles sets the profiles the jobs of the snapshot can select.
+func (s *Simulator) WithProfiles(profiles []conf.Profile) *Simulator {
+	s.profiles = profiles
+	return s
+}
+
+// Run opens a session against a fake cache built from the snapshot, executes
+// the configured actions once and returns the resulting decisions.
+func (s *Simulator) Run(snapshot *api.ClusterInfo) (*Result, error) {
+	sc, err := newSimulatedCache(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sc.Run(stopCh)
+
+	conf.EnabledActionMap = make(map[string]bool)
+	for _, action := range s.actions {
+		conf.EnabledActionMap[action.Name()] = true
+	}
+
+	ssn := framework.OpenSessionWithProfiles(sc, s.tiers, s.profiles, s.configurations)
+	ssn.EnableTrace()
+	for _, action := range s.actions {
+		klog.V(3).Infof("Simulating action <%s>", action.Name())
+		ssn.SetCurrentAction(action.Name())
+		action.Execute(ssn)
+	}
+	framework.CloseSession(ssn)
+
+	result := sc.result()
+	result.Traces = ssn.Info().Traces
+	return result, nil
+}
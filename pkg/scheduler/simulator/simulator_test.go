@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildSnapshot(t *testing.T) *api.ClusterInfo {
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+	sc.AddPod(util.BuildPod("ns1", "p3", "", v1.PodPending, api.BuildResourceList("3", "1G"), "pg2", make(map[string]string), make(map[string]string)))
+
+	// Go through the dump format, as the simulator does with a real dump.
+	buf := &bytes.Buffer{}
+	if err := cache.EncodeSnapshot(buf, sc.Snapshot()); err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	snapshot, err := cache.LoadSnapshot(buf)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	return snapshot
+}
+
+func TestSimulatorRun(t *testing.T) {
+	framework.RegisterPluginBuilder(gang.PluginName, gang.New)
+	framework.RegisterPluginBuilder(proportion.PluginName, proportion.New)
+	defer framework.CleanupPluginBuilders()
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobReady: &trueValue,
+					EnabledJobOrder: &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledQueueOrder:  &trueValue,
+					EnabledReclaimable: &trueValue,
+					EnabledAllocatable: &trueValue,
+				},
+			},
+		},
+	}
+
+	sim := New([]framework.Action{allocate.New()}, tiers, nil)
+	result, err := sim.Run(buildSnapshot(t))
+	if err != nil {
+		t.Fatalf("simulation failed: %v", err)
+	}
+
+	expectBindings := map[string]string{
+		"ns1/p1": "n1",
+		"ns1/p2": "n1",
+	}
+	if !reflect.DeepEqual(expectBindings, result.Bindings) {
+		t.Errorf("expected bindings %v, got %v", expectBindings, result.Bindings)
+	}
+	if len(result.Evictions) != 0 {
+		t.Errorf("expected no evictions, got %v", result.Evictions)
+	}
+
+	if pg, found := result.PodGroups["ns1/pg1"]; !found || pg.Phase != scheduling.PodGroupRunning {
+		t.Errorf("expected podgroup ns1/pg1 to be running, got %v", pg)
+	}
+
+	pg, found := result.PodGroups["ns1/pg2"]
+	if !found {
+		t.Fatalf("expected podgroup ns1/pg2 in result")
+	}
+	unschedulable := false
+	for _, c := range pg.Conditions {
+		if c.Type == scheduling.PodGroupUnschedulableType {
+			unschedulable = true
+		}
+	}
+	if !unschedulable {
+		t.Errorf("expected podgroup ns1/pg2 to be unschedulable, got %v", pg.Conditions)
+	}
+}
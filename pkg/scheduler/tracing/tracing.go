@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// TracerName is the name of the tracer of the scheduler.
+	TracerName = "volcano.sh/volcano/pkg/scheduler"
+	// ServiceName is the name of the service the spans are exported for.
+	ServiceName = "volcano-scheduler"
+)
+
+// The attributes of the spans.
+const (
+	SessionKey         = attribute.Key("volcano.session")
+	ActionKey          = attribute.Key("volcano.action")
+	PluginKey          = attribute.Key("volcano.plugin")
+	ProfileKey         = attribute.Key("volcano.profile")
+	JobKey             = attribute.Key("volcano.job")
+	JobNamespaceKey    = attribute.Key("volcano.job.namespace")
+	JobMinAvailableKey = attribute.Key("volcano.job.min_available")
+	JobPendingTasksKey = attribute.Key("volcano.job.pending_tasks")
+	QueueKey           = attribute.Key("volcano.queue")
+	AllocatedTasksKey  = attribute.Key("volcano.allocated_tasks")
+	PipelinedTasksKey  = attribute.Key("volcano.pipelined_tasks")
+	AllocateResultKey  = attribute.Key("volcano.allocate.result")
+)
+
+// The results of an allocate attempt of a job.
+const (
+	ResultCommitted = "committed"
+	ResultPipelined = "pipelined"
+	ResultDiscarded = "discarded"
+)
+
+// Options is the configuration of the exporter of the spans.
+type Options struct {
+	// Endpoint is the address of the OTLP gRPC collector, e.g. otel-collector:4317.
+	Endpoint string
+	// Insecure disables the transport security of the connection to the collector.
+	Insecure bool
+	// SampleRatio is the ratio of the scheduling cycles which are traced, between 0 and 1.
+	SampleRatio float64
+}
+
+// Start exports the spans of the scheduler to the OTLP collector of the options,
+// the returned function flushes the pending spans and stops the export.
+// Until Start is called, the spans of the scheduler are not recorded.
+func Start(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if len(opts.Endpoint) == 0 {
+		return nil, fmt.Errorf("the endpoint of the OTLP collector is required")
+	}
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, fmt.Errorf("the sample ratio %v is not between 0 and 1", opts.SampleRatio)
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter of %s: %v", opts.Endpoint, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the scheduler, the spans it starts are
+// not recorded unless a tracer provider is set, e.g. by Start.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// JobAttributes returns the attributes of the spans about the job.
+func JobAttributes(job *api.JobInfo) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		JobKey.String(job.Name),
+		JobNamespaceKey.String(job.Namespace),
+		QueueKey.String(string(job.Queue)),
+		JobMinAvailableKey.Int(int(job.MinAvailable)),
+		JobPendingTasksKey.Int(len(job.TaskStatusIndex[api.Pending])),
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/tracing"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// collector is an in-process OTLP collector keeping the spans it receives.
+type collector struct {
+	collectortracepb.UnimplementedTraceServiceServer
+
+	mutex sync.Mutex
+	spans []*tracepb.Span
+}
+
+func (c *collector) Export(_ context.Context, req *collectortracepb.ExportTraceServiceRequest) (*collectortracepb.ExportTraceServiceResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	return &collectortracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *collector) find(name string) *tracepb.Span {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, span := range c.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func startCollector(t *testing.T) (*collector, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	c := &collector{}
+	server := grpc.NewServer()
+	collectortracepb.RegisterTraceServiceServer(server, c)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return c, listener.Addr().String()
+}
+
+func attribute(span *tracepb.Span, key string) *commonpb.AnyValue {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+func TestSchedulingCycleSpans(t *testing.T) {
+	c, endpoint := startCollector(t)
+	shutdown, err := tracing.Start(context.Background(), tracing.Options{Endpoint: endpoint, Insecure: true, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("failed to start tracing: %v", err)
+	}
+
+	options.Default()
+	test := uthelper.TestCommonStruct{
+		Plugins: map[string]framework.PluginBuilder{gang.PluginName: gang.New},
+		PodGroups: []*schedulingv1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		},
+		Queues: []*schedulingv1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+	}
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobReady: &trueValue,
+				},
+			},
+		},
+	}
+	ssn := test.RegisterSession(tiers, nil)
+	ssn.StartSpan("Execute", tracing.ActionKey.String("allocate"))
+	test.Run([]framework.Action{allocate.New()})
+	ssn.EndSpan()
+	test.Close()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shut tracing down: %v", err)
+	}
+
+	session, open, closing, execute, alloc := c.find("Session"), c.find("OnSessionOpen"), c.find("OnSessionClose"), c.find("Execute"), c.find("Allocate")
+	for name, span := range map[string]*tracepb.Span{"Session": session, "OnSessionOpen": open, "OnSessionClose": closing, "Execute": execute, "Allocate": alloc} {
+		if span == nil {
+			t.Fatalf("expected span %s to be exported", name)
+		}
+	}
+
+	if len(session.ParentSpanId) != 0 {
+		t.Errorf("expected the span of the session to be a root span")
+	}
+	for _, span := range []*tracepb.Span{open, closing, execute} {
+		if !bytes.Equal(span.ParentSpanId, session.SpanId) {
+			t.Errorf("expected span %s to be a child of the span of the session", span.Name)
+		}
+	}
+	if !bytes.Equal(alloc.ParentSpanId, execute.SpanId) {
+		t.Errorf("expected the span of the allocate attempt to be a child of the span of the action")
+	}
+
+	if v := attribute(open, string(tracing.PluginKey)); v.GetStringValue() != gang.PluginName {
+		t.Errorf("expected plugin attribute %s, got %v", gang.PluginName, v)
+	}
+	for key, expected := range map[string]string{
+		string(tracing.JobKey):            "pg1",
+		string(tracing.JobNamespaceKey):   "c1",
+		string(tracing.QueueKey):          "q1",
+		string(tracing.AllocateResultKey): tracing.ResultCommitted,
+	} {
+		if v := attribute(alloc, key); v.GetStringValue() != expected {
+			t.Errorf("expected attribute %s of the allocate attempt to be %s, got %v", key, expected, v)
+		}
+	}
+	if v := attribute(alloc, string(tracing.AllocatedTasksKey)); v.GetIntValue() != 1 {
+		t.Errorf("expected 1 allocated task, got %v", v)
+	}
+}
@@ -45,13 +45,13 @@ tiers:
   - name: nodeorder
 `
 
-func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []conf.Configuration, map[string]string, error) {
+func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []conf.Profile, []conf.Configuration, map[string]string, error) {
 	var actions []framework.Action
 
 	schedulerConf := &conf.SchedulerConfiguration{}
 
 	if err := yaml.Unmarshal([]byte(confStr), schedulerConf); err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 	// Set default settings for each plugin if not set
 	for i, tier := range schedulerConf.Tiers {
@@ -71,7 +71,26 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 			plugins.ApplyPluginConfDefaults(&schedulerConf.Tiers[i].Plugins[j])
 		}
 		if hdrf && proportion {
-			return nil, nil, nil, nil, fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
+			return nil, nil, nil, nil, nil, fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
+		}
+	}
+
+	profileNames := map[string]bool{}
+	for i, profile := range schedulerConf.Profiles {
+		if len(profile.Name) == 0 {
+			return nil, nil, nil, nil, nil, fmt.Errorf("profile %d has no name", i)
+		}
+		if profileNames[profile.Name] {
+			return nil, nil, nil, nil, nil, fmt.Errorf("profile %s is configured more than once", profile.Name)
+		}
+		profileNames[profile.Name] = true
+		if err := checkPlacementPlugins(profile); err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+		for j, tier := range profile.Tiers {
+			for k := range tier.Plugins {
+				plugins.ApplyPluginConfDefaults(&schedulerConf.Profiles[i].Tiers[j].Plugins[k])
+			}
 		}
 	}
 
@@ -80,11 +99,11 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 		if action, found := framework.GetAction(strings.TrimSpace(actionName)); found {
 			actions = append(actions, action)
 		} else {
-			return nil, nil, nil, nil, fmt.Errorf("failed to find Action %s, ignore it", actionName)
+			return nil, nil, nil, nil, nil, fmt.Errorf("failed to find Action %s, ignore it", actionName)
 		}
 	}
 
-	return actions, schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
+	return actions, schedulerConf.Tiers, schedulerConf.Profiles, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
 }
 
 func runSchedulerSocket() {
@@ -201,7 +201,7 @@ tiers:
 
 	var expectedConfigurations []conf.Configuration
 
-	_, tiers, configurations, _, err := UnmarshalSchedulerConf(configuration)
+	_, tiers, _, configurations, _, err := UnmarshalSchedulerConf(configuration)
 	if err != nil {
 		t.Errorf("Failed to load Scheduler configuration: %v", err)
 	}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// conflictingPlugins are plugins which must not be enabled together as they
+// divide the queue resources in different ways.
+var conflictingPlugins = [][2]string{
+	{"proportion", "capacity"},
+}
+
+// ValidateSchedulerConf checks the scheduler configuration more strictly than
+// UnmarshalSchedulerConf and returns all problems found: unknown fields,
+// unknown actions and plugins, plugin arguments not matching the argument
+// schema of the plugin, conflicting plugins, plugins failing to build and
+// profiles without or with duplicated names.
+func ValidateSchedulerConf(confStr string) []error {
+	var errs []error
+
+	schedulerConf := &conf.SchedulerConfiguration{}
+	if err := yaml.UnmarshalStrict([]byte(confStr), schedulerConf); err != nil {
+		typeErr, ok := err.(*yaml.TypeError)
+		if !ok {
+			return []error{err}
+		}
+		// Strict decoding keeps going after unknown fields, so the rest can still be checked.
+		for _, msg := range typeErr.Errors {
+			errs = append(errs, fmt.Errorf("%s", msg))
+		}
+	}
+
+	actionNames := map[string]bool{}
+	for _, name := range strings.Split(schedulerConf.Actions, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			errs = append(errs, fmt.Errorf("empty action name in actions %q", schedulerConf.Actions))
+			continue
+		}
+		if _, found := framework.GetAction(name); !found {
+			errs = append(errs, fmt.Errorf("unknown action %q", name))
+		}
+		if actionNames[name] {
+			errs = append(errs, fmt.Errorf("action %q is configured more than once", name))
+		}
+		actionNames[name] = true
+	}
+
+	for _, configuration := range schedulerConf.Configurations {
+		if _, found := framework.GetAction(configuration.Name); !found {
+			errs = append(errs, fmt.Errorf("configurations: unknown action %q", configuration.Name))
+		} else if !actionNames[configuration.Name] {
+			errs = append(errs, fmt.Errorf("configurations: action %q is not enabled in actions", configuration.Name))
+		}
+	}
+
+	enabled, tierErrs := validateTiers("", schedulerConf.Tiers)
+	errs = append(errs, tierErrs...)
+	hdrf := false
+	for _, tier := range schedulerConf.Tiers {
+		for _, option := range tier.Plugins {
+			if option.Name == "drf" && option.EnabledHierarchy != nil && *option.EnabledHierarchy {
+				hdrf = true
+			}
+		}
+	}
+
+	profileNames := map[string]bool{}
+	for i, profile := range schedulerConf.Profiles {
+		if len(profile.Name) == 0 {
+			errs = append(errs, fmt.Errorf("profile %d has no name", i))
+			continue
+		}
+		if profileNames[profile.Name] {
+			errs = append(errs, fmt.Errorf("profile %q is configured more than once", profile.Name))
+		}
+		profileNames[profile.Name] = true
+		_, tierErrs := validateTiers(fmt.Sprintf("profile %q ", profile.Name), profile.Tiers)
+		errs = append(errs, tierErrs...)
+		if err := checkPlacementPlugins(profile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if hdrf && enabled["proportion"] {
+		errs = append(errs, fmt.Errorf("proportion and drf with hierarchy enabled conflicts"))
+	}
+	for _, plugins := range conflictingPlugins {
+		if enabled[plugins[0]] && enabled[plugins[1]] {
+			errs = append(errs, fmt.Errorf("%s and %s conflicts", plugins[0], plugins[1]))
+		}
+	}
+
+	if len(errs) == 0 {
+		// Dry run of the loader used by the scheduler.
+		if _, _, _, _, _, err := UnmarshalSchedulerConf(confStr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// checkPlacementPlugins checks that the plugins of the profile can be configured in profiles,
+// only the placement callbacks of the plugins are dispatched by profile.
+func checkPlacementPlugins(profile conf.Profile) error {
+	for i, tier := range profile.Tiers {
+		for _, option := range tier.Plugins {
+			if !framework.IsPlacementPlugin(option.Name) {
+				return fmt.Errorf("profile %q tier %d: plugin %q cannot be configured in profiles, only plugins ordering tasks and filtering and scoring nodes can",
+					profile.Name, i, option.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTiers checks the plugins in the tiers and returns the enabled ones.
+func validateTiers(prefix string, tiers []conf.Tier) (map[string]bool, []error) {
+	var errs []error
+	enabled := map[string]bool{}
+	for i, tier := range tiers {
+		for _, option := range tier.Plugins {
+			pluginPrefix := fmt.Sprintf("%stier %d: plugin %q", prefix, i, option.Name)
+			if enabled[option.Name] {
+				errs = append(errs, fmt.Errorf("%s is configured more than once", pluginPrefix))
+			}
+			enabled[option.Name] = true
+
+			builder, found := framework.GetPluginBuilder(option.Name)
+			if !found {
+				errs = append(errs, fmt.Errorf("%s is not registered", pluginPrefix))
+				continue
+			}
+			if schema, found := framework.GetPluginArgumentSchema(option.Name); found {
+				for _, err := range schema.Validate(option.Arguments) {
+					errs = append(errs, fmt.Errorf("%s: %v", pluginPrefix, err))
+				}
+			}
+			if err := buildPlugin(builder, option.Arguments); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", pluginPrefix, err))
+			}
+		}
+	}
+	return enabled, errs
+}
+
+// buildPlugin builds the plugin once to catch arguments the plugin fails on.
+func buildPlugin(builder framework.PluginBuilder, arguments framework.Arguments) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to build plugin: %v", r)
+		}
+	}()
+	builder(arguments)
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchedulerConf(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		errs   []string
+	}{
+		{
+			name:   "default configuration",
+			config: DefaultSchedulerConf,
+		},
+		{
+			name: "plugin arguments",
+			config: `
+actions: "enqueue, allocate, backfill"
+tiers:
+- plugins:
+  - name: gang
+  - name: binpack
+    arguments:
+      binpack.weight: 10
+      binpack.resources: nvidia.com/gpu
+      binpack.resources.nvidia.com/gpu: 2
+  - name: predicates
+    arguments:
+      predicate.CacheEnable: true
+`,
+		},
+		{
+			name: "misspelled names",
+			config: `
+actions: "enqueue, alocate"
+tiers:
+- plugins:
+  - name: gangg
+  - name: drf
+    enableHierachy: true
+`,
+			errs: []string{
+				`field enableHierachy not found`,
+				`unknown action "alocate"`,
+				`plugin "gangg" is not registered`,
+			},
+		},
+		{
+			name: "malformed arguments",
+			config: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: nodeorder
+    arguments:
+      leastrequested.weight: "1"
+      leastrequest.weight: 1
+  - name: gang
+    arguments:
+      foo: bar
+  - name: tdm
+    arguments:
+      tdm.evict.period: 1m
+      tdm.revocable-zone.rz1: 10
+`,
+			errs: []string{
+				`unknown argument "leastrequest.weight"`,
+				`argument "leastrequested.weight": expected int, got string`,
+				`plugin "gang": unknown argument "foo"`,
+				`argument "tdm.revocable-zone.rz1": expected string, got int`,
+				`plugin "tdm": failed to build plugin`,
+			},
+		},
+		{
+			name: "conflicting plugins",
+			config: `
+actions: "allocate"
+configurations:
+- name: preempt
+tiers:
+- plugins:
+  - name: drf
+    enableHierarchy: true
+  - name: proportion
+- plugins:
+  - name: capacity
+  - name: proportion
+`,
+			errs: []string{
+				`action "preempt" is not enabled in actions`,
+				`plugin "proportion" is configured more than once`,
+				`proportion and drf with hierarchy enabled conflicts`,
+				`proportion and capacity conflicts`,
+			},
+		},
+		{
+			name: "profiles",
+			config: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+  - name: nodeorder
+profiles:
+- name: inference
+  tiers:
+  - plugins:
+    - name: binpack
+      arguments:
+        binpack.weight: 10
+    - name: proportion
+- name: training
+  tiers:
+  - plugins:
+    - name: nodeorder
+      arguments:
+        leastrequested.weight: "0"
+- name: inference
+- tiers:
+  - plugins:
+    - name: spread
+`,
+			errs: []string{
+				`profile "inference" tier 0: plugin "proportion" cannot be configured in profiles`,
+				`profile "training" tier 0: plugin "nodeorder": argument "leastrequested.weight": expected int, got string`,
+				`profile "inference" is configured more than once`,
+				`profile 3 has no name`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		errs := ValidateSchedulerConf(c.config)
+		if len(errs) != len(c.errs) {
+			t.Errorf("case %s: expected %d errors, got %v", c.name, len(c.errs), errs)
+			continue
+		}
+		for i, expected := range c.errs {
+			if !strings.Contains(errs[i].Error(), expected) {
+				t.Errorf("case %s: expected error %d to contain %q, got %q", c.name, i, expected, errs[i])
+			}
+		}
+	}
+}
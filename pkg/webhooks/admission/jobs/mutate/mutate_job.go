@@ -27,8 +27,10 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/launcher"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
 	commonutil "volcano.sh/volcano/pkg/util"
 	"volcano.sh/volcano/pkg/webhooks/router"
@@ -231,12 +233,14 @@ func patchDefaultPlugins(job *v1alpha1.Job) *patchOperation {
 		plugins[k] = v
 	}
 
-	// Because the tensorflow-plugin and mpi-plugin depends on svc-plugin.
+	// Because the tensorflow-plugin, mpi-plugin, pytorch-plugin, ray-plugin and launcher-plugin depends on svc-plugin.
 	// If the svc-plugin is not defined, we should add it.
 	_, hasTf := job.Spec.Plugins[tensorflow.TFPluginName]
 	_, hasMPI := job.Spec.Plugins[mpi.MPIPluginName]
 	_, hasPytorch := job.Spec.Plugins[pytorch.PytorchPluginName]
-	if hasTf || hasMPI || hasPytorch {
+	_, hasRay := job.Spec.Plugins[ray.RayPluginName]
+	_, hasLauncher := job.Spec.Plugins[launcher.LauncherPluginName]
+	if hasTf || hasMPI || hasPytorch || hasRay || hasLauncher {
 		if _, ok := plugins["svc"]; !ok {
 			plugins["svc"] = []string{}
 		}
@@ -36,10 +36,14 @@ import (
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/job/checkpoint"
 	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	controllerLauncher "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/launcher"
 	controllerMpi "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
+	controllerPytorch "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	controllerRay "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -156,6 +160,26 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 		}
 	}
 
+	if err := validateRayHeadTask(job); err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	if err := validatePytorchElastic(job); err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	if err := validateLauncherTasks(job); err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	if _, err := checkpoint.GetPolicy(job); err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
 	hasDependenciesBetweenTasks := false
 	for index, task := range job.Spec.Tasks {
 		if task.DependsOn != nil {
@@ -245,6 +269,61 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 	return msg
 }
 
+// validateRayHeadTask checks that a job with the ray plugin has a single head, the workers join the cluster through it.
+func validateRayHeadTask(job *v1alpha1.Job) error {
+	if _, ok := job.Spec.Plugins[controllerRay.RayPluginName]; !ok {
+		return nil
+	}
+	rp := controllerRay.NewInstance(job.Spec.Plugins[controllerRay.RayPluginName])
+	headIndex := helpers.GetTaskIndexUnderJob(rp.GetHeadName(), job)
+	if headIndex == -1 {
+		return fmt.Errorf("the specified ray head task %s was not found", rp.GetHeadName())
+	}
+	if job.Spec.Tasks[headIndex].Replicas != 1 {
+		return fmt.Errorf("the specified ray head task %s must have exactly one replica", rp.GetHeadName())
+	}
+	return nil
+}
+
+// validatePytorchElastic checks that a job with the pytorch plugin in elastic mode sets the maximum number of nodes,
+// and that it is not scaled beyond it as the pods above it would not join the rendezvous.
+func validatePytorchElastic(job *v1alpha1.Job) error {
+	maxNodes, elastic := controllerPytorch.ElasticMaxNodes(job)
+	if !elastic {
+		return nil
+	}
+	if maxNodes <= 0 {
+		return fmt.Errorf("the pytorch plugin requires --max-nodes in elastic mode")
+	}
+	var replicas int32
+	for _, task := range job.Spec.Tasks {
+		replicas += task.Replicas
+	}
+	if replicas > maxNodes {
+		return fmt.Errorf("job has %d replicas, more than the %d max nodes of the pytorch plugin in elastic mode", replicas, maxNodes)
+	}
+	return nil
+}
+
+// validateLauncherTasks checks that a job with the launcher plugin has its launcher and worker tasks,
+// and that the hostfile can be rendered with the formats of the plugin.
+func validateLauncherTasks(job *v1alpha1.Job) error {
+	if _, ok := job.Spec.Plugins[controllerLauncher.LauncherPluginName]; !ok {
+		return nil
+	}
+	lp := controllerLauncher.NewInstance(job.Spec.Plugins[controllerLauncher.LauncherPluginName])
+	if err := lp.Validate(); err != nil {
+		return err
+	}
+	if helpers.GetTaskIndexUnderJob(lp.GetLauncherName(), job) == -1 {
+		return fmt.Errorf("the specified launcher task %s was not found", lp.GetLauncherName())
+	}
+	if helpers.GetTaskIndexUnderJob(lp.GetWorkerName(), job) == -1 {
+		return fmt.Errorf("the specified launcher worker task %s was not found", lp.GetWorkerName())
+	}
+	return nil
+}
+
 func validateJobUpdate(old, new *v1alpha1.Job) error {
 	var totalReplicas int32
 	for _, task := range new.Spec.Tasks {
@@ -266,6 +345,12 @@ func validateJobUpdate(old, new *v1alpha1.Job) error {
 	if new.Spec.MinAvailable > totalReplicas {
 		return fmt.Errorf("job 'minAvailable' must not be greater than total replicas")
 	}
+	if err := validateRayHeadTask(new); err != nil {
+		return err
+	}
+	if err := validatePytorchElastic(new); err != nil {
+		return err
+	}
 	if new.Spec.MinAvailable < 0 {
 		return fmt.Errorf("job 'minAvailable' must be >= 0")
 	}
@@ -1217,6 +1217,391 @@ func TestValidateJobCreate(t *testing.T) {
 			ret:            "job has dependencies between tasks, but doesn't form a directed acyclic graph(DAG)",
 			ExpectErr:      true,
 		},
+		{
+			Name: "ray job with one head",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ray-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"ray": {},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "head",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "",
+			ExpectErr:      false,
+		},
+		{
+			Name: "ray job with two heads",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ray-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"ray": {},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "head",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "the specified ray head task head must have exactly one replica",
+			ExpectErr:      true,
+		},
+		{
+			Name: "ray job without head",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ray-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"ray": {},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "driver",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "rayproject/ray:2.9.0",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "the specified ray head task head was not found",
+			ExpectErr:      true,
+		},
+		{
+			Name: "elastic pytorch job",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pytorch-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"pytorch": {"--elastic", "--max-nodes=4"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "pytorch/pytorch:2.1.0-cuda11.8-cudnn8-runtime",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "",
+			ExpectErr:      false,
+		},
+		{
+			Name: "elastic pytorch job without max nodes",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pytorch-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"pytorch": {"--elastic"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "pytorch/pytorch:2.1.0-cuda11.8-cudnn8-runtime",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "the pytorch plugin requires --max-nodes in elastic mode",
+			ExpectErr:      true,
+		},
+		{
+			Name: "elastic pytorch job beyond max nodes",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pytorch-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"pytorch": {"--elastic", "--max-nodes=2"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "pytorch/pytorch:2.1.0-cuda11.8-cudnn8-runtime",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "job has 3 replicas, more than the 2 max nodes of the pytorch plugin in elastic mode",
+			ExpectErr:      true,
+		},
+		{
+			Name: "launcher job without worker",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "launcher-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"launcher": {"--preset=deepspeed"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "launcher",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "deepspeed/deepspeed:latest",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "trainer",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "deepspeed/deepspeed:latest",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "the specified launcher worker task worker was not found",
+			ExpectErr:      true,
+		},
+		{
+			Name: "launcher job with unknown preset",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "launcher-job",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Plugins: map[string][]string{
+						"launcher": {"--preset=unknown", "--worker=trainer"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "launcher",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "deepspeed/deepspeed:latest",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "trainer",
+							Replicas: 2,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "deepspeed/deepspeed:latest",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "unknown launcher preset unknown",
+			ExpectErr:      true,
+		},
+		{
+			Name: "job with checkpoint policy without command",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checkpoint-job",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						"volcano.sh/checkpoint-policy": "exec",
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "checkpoint policy exec requires the annotation volcano.sh/checkpoint-command",
+			ExpectErr:      true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1271,6 +1656,7 @@ func TestValidateJobUpdate(t *testing.T) {
 		addTask        bool
 		mutateTaskName bool
 		mutateSpec     bool
+		plugins        map[string][]string
 		expectErr      bool
 	}{
 		{
@@ -1336,6 +1722,26 @@ func TestValidateJobUpdate(t *testing.T) {
 			mutateSpec:     true,
 			expectErr:      true,
 		},
+		{
+			name:           "scale up elastic pytorch job",
+			replicas:       6,
+			minAvailable:   5,
+			addTask:        false,
+			mutateTaskName: false,
+			mutateSpec:     false,
+			plugins:        map[string][]string{"pytorch": {"--elastic", "--max-nodes=6"}},
+			expectErr:      false,
+		},
+		{
+			name:           "invalid scale up elastic pytorch job beyond max nodes",
+			replicas:       7,
+			minAvailable:   5,
+			addTask:        false,
+			mutateTaskName: false,
+			mutateSpec:     false,
+			plugins:        map[string][]string{"pytorch": {"--elastic", "--max-nodes=6"}},
+			expectErr:      true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1344,6 +1750,8 @@ func TestValidateJobUpdate(t *testing.T) {
 			new := newJob()
 			new.ResourceVersion = "502593"
 			new.Status.Succeeded = 2
+			old.Spec.Plugins = tc.plugins
+			new.Spec.Plugins = tc.plugins
 
 			new.Spec.MinAvailable = tc.minAvailable
 			new.Spec.Tasks[0].Replicas = tc.replicas